@@ -2,18 +2,25 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
 	"play-to-win-api/internal/config"
 	"play-to-win-api/internal/delivery/http/handler"
 	"play-to-win-api/internal/delivery/http/middleware"
 	route "play-to-win-api/internal/delivery/http/routes"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/internal/event"
 	"play-to-win-api/internal/repository/mongodb"
+	"play-to-win-api/internal/storage"
 	"play-to-win-api/internal/usecase"
+	"play-to-win-api/internal/worker"
 	mongoClient "play-to-win-api/pkg/mongodb"
 	"play-to-win-api/pkg/validator"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func main() {
@@ -33,12 +40,51 @@ func main() {
 		log.Fatal("Failed to connect to MongoDB:", err)
 	}
 
+	if err := mongodb.EnsureIndexes(context.Background(), db); err != nil {
+		log.Fatal("Failed to create MongoDB indexes:", err)
+	}
+
 	categoryRepo := mongodb.NewCategoryRepository(db)
 	userRepo := mongodb.NewUserRepository(db)
 	productRepo := mongodb.NewProductRepository(db)
 	campaignRepo := mongodb.NewCampaignRepository(db)
+	discountRuleRepo := mongodb.NewDiscountRuleRepository(db)
 	cartRepo := mongodb.NewCartRepository(db)
 	cartItemRepo := mongodb.NewCartItemRepository(db)
+	reviewRepo := mongodb.NewReviewRepository(db)
+	stockMovementRepo := mongodb.NewStockMovementRepository(db)
+	priceChangeRepo := mongodb.NewPriceChangeRepository(db)
+	wishlistRepo := mongodb.NewWishlistRepository(db)
+	notificationRepo := mongodb.NewNotificationRepository(db)
+	recommendationRepo := mongodb.NewRecommendationRepository(db)
+	leaderLock := mongodb.NewLeaderLock(db)
+	orderRepo := mongodb.NewOrderRepository(db)
+	campaignRedemptionRepo := mongodb.NewCampaignRedemptionRepository(db)
+	segmentRepo := mongodb.NewSegmentRepository(db)
+	exchangeRateRepo := mongodb.NewExchangeRateRepository(db)
+	taxRegionRepo := mongodb.NewTaxRegionRepository(db)
+	productSearchIndex := mongodb.NewProductSearchIndex(db)
+
+	blobStore, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		log.Fatal("Failed to initialise blob storage:", err)
+	}
+
+	rounding := domain.Rounding{
+		Mode:  domain.RoundingMode(cfg.Pricing.RoundingMode),
+		Scope: domain.RoundingScope(cfg.Pricing.DiscountRounding),
+	}
+	if !rounding.Valid() {
+		log.Fatal("ROUNDING_MODE must be half_even or half_up and DISCOUNT_ROUNDING per_order or per_line")
+	}
+
+	v := validator.NewValidator()
+
+	eventBus := event.NewBus()
+	eventBus.Subscribe(domain.EventProductLowStock, func(ctx context.Context, e domain.Event) {
+		alert := e.Payload.(domain.LowStockAlert)
+		log.Printf("Low stock: %s (%s) has %d left, threshold %d", alert.ProductName, alert.ProductID.Hex(), alert.Stock, alert.Threshold)
+	})
 
 	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
 	authUseCase := usecase.NewAuthUseCase(
@@ -48,32 +94,146 @@ func main() {
 		24*time.Hour,
 		7*24*time.Hour,
 	)
-	productUseCase := usecase.NewProductUseCase(productRepo)
-	campaignUseCase := usecase.NewCampaignUseCase(campaignRepo)
-	cartUseCase := usecase.NewCartUseCase(cartRepo)
-	cartItemUseCase := usecase.NewCartItemUseCase(cartItemRepo)
-	appliedDiscountUseCase := usecase.NewAppliedDiscountUseCase()
+	productUseCase := usecase.NewProductUseCase(productRepo, categoryRepo, productSearchIndex, priceChangeRepo, eventBus)
+	campaignUseCase := usecase.NewCampaignUseCase(campaignRepo, discountRuleRepo, orderRepo, segmentRepo, eventBus)
+	productImageUseCase := usecase.NewProductImageUseCase(productRepo, blobStore, cfg.Storage.MaxImageBytes, cfg.Storage.MaxImagePixels, cfg.Storage.ThumbnailSize)
+	inventoryUseCase := usecase.NewInventoryUseCase(productRepo, stockMovementRepo, eventBus, cfg.Inventory.LowStockThreshold)
+	catalogTransferUseCase := usecase.NewCatalogTransferUseCase(productRepo, categoryRepo, productSearchIndex, priceChangeRepo, eventBus, inventoryUseCase, v)
+	reviewUseCase := usecase.NewReviewUseCase(reviewRepo, productRepo, orderRepo, userRepo)
+	pricingUseCase := usecase.NewPricingUseCase(productRepo, priceChangeRepo, productSearchIndex, eventBus)
+	exchangeRateUseCase := usecase.NewExchangeRateUseCase(exchangeRateRepo, rounding.Mode)
+	taxUseCase := usecase.NewTaxUseCase(taxRegionRepo, usecase.NewVATCalculator(rounding.Mode), cfg.Pricing.TaxRegion)
+	cartUseCase := usecase.NewCartUseCase(cartRepo, exchangeRateUseCase, taxUseCase)
+	cartItemUseCase := usecase.NewCartItemUseCase(cartItemRepo, productRepo, categoryRepo, cartRepo, exchangeRateUseCase)
+	appliedDiscountUseCase := usecase.NewAppliedDiscountUseCase(categoryRepo, rounding)
+	segmentUseCase := usecase.NewSegmentUseCase(segmentRepo, campaignRepo, userRepo, orderRepo)
+	discountRuleUseCase := usecase.NewDiscountRuleUseCase(discountRuleRepo, campaignRepo, appliedDiscountUseCase, segmentUseCase, rounding)
+	campaignSimulationUseCase := usecase.NewCampaignSimulationUseCase(orderRepo, segmentUseCase, appliedDiscountUseCase, exchangeRateUseCase, rounding)
+	wishlistUseCase := usecase.NewWishlistUseCase(wishlistRepo, productRepo, cartRepo, notificationRepo, cartItemUseCase)
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo)
+	orderUseCase := usecase.NewOrderUseCase(orderRepo, cartRepo, cartItemRepo, campaignRepo, campaignRedemptionRepo, discountRuleUseCase, inventoryUseCase, exchangeRateUseCase, taxUseCase, eventBus)
+	recommendationUseCase := usecase.NewRecommendationUseCase(recommendationRepo, cartRepo, cartItemRepo, domain.RecommendationSettings{
+		MinSupport:  cfg.Recommendations.MinSupport,
+		PerProduct:  cfg.Recommendations.PerProduct,
+		MaxCartSize: cfg.Recommendations.MaxCartSize,
+	})
 
-	e := echo.New()
+	eventBus.Subscribe(domain.EventCampaignStarted, func(ctx context.Context, e domain.Event) {
+		campaign := e.Payload.(domain.CampaignTransition)
+		log.Printf("Campaign started: %s (%s), runs until %s", campaign.Name, campaign.CampaignID.Hex(), campaign.EndDate.Format(time.RFC3339))
+	})
+	eventBus.Subscribe(domain.EventCampaignEnded, func(ctx context.Context, e domain.Event) {
+		campaign := e.Payload.(domain.CampaignTransition)
+		log.Printf("Campaign ended: %s (%s)", campaign.Name, campaign.CampaignID.Hex())
+	})
+	eventBus.Subscribe(domain.EventCampaignExhausted, func(ctx context.Context, e domain.Event) {
+		campaign := e.Payload.(domain.CampaignTransition)
+		log.Printf("Campaign exhausted its budget or redemption limit and was deactivated: %s (%s)", campaign.Name, campaign.CampaignID.Hex())
+	})
+	eventBus.Subscribe(domain.EventProductPriceChanged, func(ctx context.Context, e domain.Event) {
+		if err := wishlistUseCase.NotifyPriceDrop(ctx, e.Payload.(domain.PriceChanged)); err != nil {
+			log.Println("Failed to send wishlist price drop notifications:", err)
+		}
+	})
+	eventBus.Subscribe(domain.EventProductBackInStock, func(ctx context.Context, e domain.Event) {
+		if err := wishlistUseCase.NotifyBackInStock(ctx, e.Payload.(domain.BackInStock)); err != nil {
+			log.Println("Failed to send wishlist back in stock notifications:", err)
+		}
+	})
 
-	v := validator.NewValidator()
+	if _, err := taxUseCase.Region(context.Background(), ""); err != nil {
+		log.Fatal("TAX_REGION must name a configured tax region:", err)
+	}
+	if n, err := categoryUseCase.EnsurePaths(context.Background()); err != nil {
+		log.Println("Failed to backfill category paths:", err)
+	} else if n > 0 {
+		log.Printf("Backfilled %d category path(s)", n)
+	}
+	if n, err := categoryUseCase.EnsureSlugs(context.Background()); err != nil {
+		log.Println("Failed to backfill category slugs:", err)
+	} else if n > 0 {
+		log.Printf("Backfilled %d category slug(s)", n)
+	}
+	if n, err := productUseCase.EnsureSlugs(context.Background()); err != nil {
+		log.Println("Failed to backfill product slugs:", err)
+	} else if n > 0 {
+		log.Printf("Backfilled %d product slug(s)", n)
+	}
+
+	e := echo.New()
 
 	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.AccessSecret)
 
 	handlers := &handler.Handlers{
-		Category: handler.NewCategoryHandler(categoryUseCase),
-		Auth:     handler.NewAuthHandler(authUseCase, v),
-		AuthMW:   authMiddleware,
-		Product:  handler.NewProductHandler(productUseCase),
-		Campaign: handler.NewCampaignHandler(campaignUseCase),
-		Cart:     handler.NewCartHandler(cartUseCase, authUseCase),
-		CartItem: handler.NewCartItemHandler(cartItemUseCase),
-		Discount: handler.NewDiscountHandler(cartItemUseCase, appliedDiscountUseCase),
+		Category:        handler.NewCategoryHandler(categoryUseCase),
+		Auth:            handler.NewAuthHandler(authUseCase, v),
+		AuthMW:          authMiddleware,
+		Product:         handler.NewProductHandler(productUseCase),
+		ProductImage:    handler.NewProductImageHandler(productImageUseCase, cfg.Storage.MaxImageBytes),
+		CatalogTransfer: handler.NewCatalogTransferHandler(catalogTransferUseCase),
+		Review:          handler.NewReviewHandler(reviewUseCase),
+		Inventory:       handler.NewInventoryHandler(inventoryUseCase),
+		Pricing:         handler.NewPricingHandler(pricingUseCase),
+		Campaign:        handler.NewCampaignHandler(campaignUseCase, campaignSimulationUseCase),
+		Cart:            handler.NewCartHandler(cartUseCase, authUseCase),
+		CartItem:        handler.NewCartItemHandler(cartItemUseCase),
+		Wishlist:        handler.NewWishlistHandler(wishlistUseCase),
+		Notification:    handler.NewNotificationHandler(notificationUseCase),
+		Recommendation:  handler.NewRecommendationHandler(recommendationUseCase),
+		Order:           handler.NewOrderHandler(orderUseCase),
+		DiscountRule:    handler.NewDiscountRuleHandler(discountRuleUseCase),
+		Segment:         handler.NewSegmentHandler(segmentUseCase),
+		ExchangeRate:    handler.NewExchangeRateHandler(exchangeRateUseCase),
+		Tax:             handler.NewTaxHandler(taxUseCase),
+		Discount:        handler.NewDiscountHandler(cartUseCase, cartItemUseCase, appliedDiscountUseCase, discountRuleUseCase, taxUseCase),
+	}
+
+	if cfg.Storage.Driver == "local" {
+		e.Static("/uploads", cfg.Storage.LocalDir)
 	}
 
 	route.SetupRoutes(e, handlers)
 
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	holder := instanceID()
+	go worker.NewPriceScheduler(pricingUseCase, leaderLock, holder, cfg.Workers.PriceSchedulerInterval).Run(workerCtx)
+	go worker.NewArchivePurger(map[string]domain.ArchivePurger{
+		"products":   productRepo,
+		"categories": categoryRepo,
+		"campaigns":  campaignRepo,
+	}, leaderLock, holder, cfg.Workers.PurgeRetention, cfg.Workers.PurgeInterval).Run(workerCtx)
+	go worker.NewCampaignScheduler(campaignUseCase, leaderLock, holder, cfg.Workers.CampaignSchedulerInterval).Run(workerCtx)
+	go worker.NewRecommendationRefresher(recommendationUseCase, leaderLock, holder, cfg.Workers.RecommendationInterval).Run(workerCtx)
+
 	if err := e.Start(":" + cfg.Server.Port); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// instanceID identifies this replica when it competes for leader locks.
+func instanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "api"
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), primitive.NewObjectID().Hex())
+}
+
+func newBlobStore(cfg config.StorageConfig) (domain.BlobStore, error) {
+	switch cfg.Driver {
+	case "s3":
+		return storage.NewS3BlobStore(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.PublicURL,
+		}, nil), nil
+	case "local":
+		return storage.NewLocalBlobStore(cfg.LocalDir, cfg.PublicURL)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
@@ -3,15 +3,21 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server  ServerConfig
-	MongoDB MongoDBConfig
-	JWT     JWTConfig
+	Server          ServerConfig
+	MongoDB         MongoDBConfig
+	JWT             JWTConfig
+	Storage         StorageConfig
+	Inventory       InventoryConfig
+	Recommendations RecommendationConfig
+	Workers         WorkerConfig
+	Pricing         PricingConfig
 }
 
 type ServerConfig struct {
@@ -33,6 +39,45 @@ type JWTConfig struct {
 	RefreshExpiresIn time.Duration
 }
 
+type StorageConfig struct {
+	Driver         string
+	LocalDir       string
+	PublicURL      string
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	MaxImageBytes  int64
+	MaxImagePixels int
+	ThumbnailSize  int
+}
+
+type InventoryConfig struct {
+	LowStockThreshold int
+}
+
+type RecommendationConfig struct {
+	MinSupport  int
+	PerProduct  int
+	MaxCartSize int
+}
+
+type PricingConfig struct {
+	RoundingMode     string
+	DiscountRounding string
+	// TaxRegion is charged on carts that do not pick a region.
+	TaxRegion string
+}
+
+type WorkerConfig struct {
+	PriceSchedulerInterval    time.Duration
+	CampaignSchedulerInterval time.Duration
+	PurgeInterval             time.Duration
+	PurgeRetention            time.Duration
+	RecommendationInterval    time.Duration
+}
+
 func LoadConfig() *Config {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found. Using system environment variables.")
@@ -55,6 +100,39 @@ func LoadConfig() *Config {
 			AccessExpiresIn:  time.Hour * 24,
 			RefreshExpiresIn: time.Hour * 24 * 7,
 		},
+		Storage: StorageConfig{
+			Driver:         getEnv("STORAGE_DRIVER", "local"),
+			LocalDir:       getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+			PublicURL:      getEnv("STORAGE_PUBLIC_URL", "http://localhost:8081/uploads"),
+			S3Endpoint:     getEnv("S3_ENDPOINT", ""),
+			S3Region:       getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:       getEnv("S3_BUCKET", ""),
+			S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+			MaxImageBytes:  int64(getEnvInt("MAX_IMAGE_BYTES", 5<<20)),
+			MaxImagePixels: getEnvInt("MAX_IMAGE_PIXELS", 40_000_000),
+			ThumbnailSize:  getEnvInt("THUMBNAIL_SIZE", 320),
+		},
+		Inventory: InventoryConfig{
+			LowStockThreshold: getEnvInt("LOW_STOCK_THRESHOLD", 5),
+		},
+		Recommendations: RecommendationConfig{
+			MinSupport:  getEnvInt("RECOMMENDATION_MIN_SUPPORT", 2),
+			PerProduct:  getEnvInt("RECOMMENDATIONS_PER_PRODUCT", 20),
+			MaxCartSize: getEnvInt("RECOMMENDATION_MAX_CART_SIZE", 50),
+		},
+		Workers: WorkerConfig{
+			PriceSchedulerInterval:    getEnvDuration("PRICE_SCHEDULER_INTERVAL", time.Minute),
+			CampaignSchedulerInterval: getEnvDuration("CAMPAIGN_SCHEDULER_INTERVAL", time.Minute),
+			PurgeInterval:             getEnvDuration("PURGE_INTERVAL", time.Hour),
+			PurgeRetention:            getEnvDuration("PURGE_RETENTION", 30*24*time.Hour),
+			RecommendationInterval:    getEnvDuration("RECOMMENDATION_INTERVAL", time.Hour),
+		},
+		Pricing: PricingConfig{
+			RoundingMode:     getEnv("ROUNDING_MODE", "half_even"),
+			DiscountRounding: getEnv("DISCOUNT_ROUNDING", "per_order"),
+			TaxRegion:        getEnv("TAX_REGION", "TH"),
+		},
 	}
 }
 
@@ -64,3 +142,29 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid value for %s, using default %d", key, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid value for %s, using default %s", key, defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
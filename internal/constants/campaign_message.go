@@ -1,11 +1,16 @@
 package constants
 
 const (
-	CampaignCreatedSuccess    = "Campaign created successfully"
-	CampaignUpdatedSuccess    = "Campaign updated successfully"
-	CampaignDeletedSuccess    = "Campaign deleted successfully"
-	CampaignRetrievedSuccess  = "Campaign retrieved successfully"
-	CampaignsRetrievedSuccess = "Campaigns retrieved successfully"
+	CampaignCreatedSuccess            = "Campaign created successfully"
+	CampaignUpdatedSuccess            = "Campaign updated successfully"
+	CampaignDeletedSuccess            = "Campaign deleted successfully"
+	CampaignRetrievedSuccess          = "Campaign retrieved successfully"
+	CampaignsRetrievedSuccess         = "Campaigns retrieved successfully"
+	CampaignRestoredSuccess           = "Campaign restored successfully"
+	CampaignsArchivedSuccess          = "Archived campaigns retrieved successfully"
+	CampaignConflictsRetrievedSuccess = "Campaign conflicts retrieved successfully"
+	CampaignStatsRetrievedSuccess     = "Campaign stats retrieved successfully"
+	CampaignSimulatedSuccess          = "Campaign simulated successfully"
 
 	CampaignNotFoundError    = "Campaign not found"
 	CampaignCreateError      = "Failed to create campaign"
@@ -6,6 +6,11 @@ const (
 	CategoryDeletedSuccess     = "Category deleted successfully"
 	CategoryRetrievedSuccess   = "Category retrieved successfully"
 	CategoriesRetrievedSuccess = "Categories retrieved successfully"
+	CategoryRestoredSuccess    = "Category restored successfully"
+	CategoriesArchivedSuccess  = "Archived categories retrieved successfully"
+
+	CategoryTreeRetrievedSuccess        = "Category tree retrieved successfully"
+	CategoryBreadcrumbsRetrievedSuccess = "Category breadcrumbs retrieved successfully"
 
 	CategoryNotFoundError    = "Category not found"
 	CategoryCreateError      = "Failed to create category"
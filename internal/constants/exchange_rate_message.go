@@ -0,0 +1,7 @@
+package constants
+
+const (
+	ExchangeRateSetSuccess        = "Exchange rate set successfully"
+	ExchangeRateDeletedSuccess    = "Exchange rate deleted successfully"
+	ExchangeRatesRetrievedSuccess = "Exchange rates retrieved successfully"
+)
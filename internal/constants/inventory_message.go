@@ -0,0 +1,7 @@
+package constants
+
+const (
+	StockAdjustedSuccess           = "Stock has been adjusted"
+	StockMovementsRetrievedSuccess = "Stock movements have been retrieved"
+	LowStockRetrievedSuccess       = "Low stock products have been retrieved"
+)
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	OrderPlacedSuccess     = "Order has been placed"
+	OrderRetrievedSuccess  = "Order has been retrieved"
+	OrdersRetrievedSuccess = "Orders have been retrieved"
+)
@@ -0,0 +1,8 @@
+package constants
+
+const (
+	PriceScheduledSuccess        = "Price change has been scheduled"
+	PriceAppliedSuccess          = "Price change has been applied"
+	PriceCancelledSuccess        = "Price change has been cancelled"
+	PriceHistoryRetrievedSuccess = "Price history has been retrieved"
+)
@@ -1,17 +1,33 @@
 package constants
 
 const (
-	ProductCreatedSuccess    = "Product created successfully"
-	ProductUpdatedSuccess    = "Product updated successfully"
-	ProductDeletedSuccess    = "Product deleted successfully"
-	ProductRetrievedSuccess  = "Product retrieved successfully"
-	ProductsRetrievedSuccess = "Products retrieved successfully"
+	ProductCreatedSuccess     = "Product created successfully"
+	ProductUpdatedSuccess     = "Product updated successfully"
+	ProductDeletedSuccess     = "Product deleted successfully"
+	ProductRetrievedSuccess   = "Product retrieved successfully"
+	ProductsRetrievedSuccess  = "Products retrieved successfully"
+	ProductsSearchedSuccess   = "Products searched successfully"
+	ProductSuggestionsSuccess = "Product suggestions retrieved successfully"
+	ProductRestoredSuccess    = "Product restored successfully"
+	ProductsArchivedSuccess   = "Archived products retrieved successfully"
 
-	ProductNotFoundError    = "Product not found"
-	ProductCreateError      = "Failed to create product"
-	ProductUpdateError      = "Failed to update product"
-	ProductDeleteError      = "Failed to delete product"
-	ProductInvalidIDError   = "Invalid product ID"
-	ProductInvalidDataError = "Invalid product data"
-	ProductDuplicateError   = "Product already exists"
+	ProductVariantCreatedSuccess = "Product variant created successfully"
+	ProductVariantUpdatedSuccess = "Product variant updated successfully"
+	ProductVariantDeletedSuccess = "Product variant deleted successfully"
+
+	ProductImageUploadedSuccess   = "Product image uploaded successfully"
+	ProductImagesReorderedSuccess = "Product images reordered successfully"
+	ProductImageDeletedSuccess    = "Product image deleted successfully"
+
+	ProductsImportedSuccess        = "Products imported successfully"
+	ProductsImportValidatedSuccess = "Product import validated successfully, no changes were saved"
+
+	ProductNotFoundError     = "Product not found"
+	ProductCreateError       = "Failed to create product"
+	ProductUpdateError       = "Failed to update product"
+	ProductDeleteError       = "Failed to delete product"
+	ProductInvalidIDError    = "Invalid product ID"
+	ProductInvalidDataError  = "Invalid product data"
+	ProductDuplicateError    = "Product already exists"
+	ProductImageMissingError = "Image file is required"
 )
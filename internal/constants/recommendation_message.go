@@ -0,0 +1,5 @@
+package constants
+
+const (
+	RecommendationsRetrievedSuccess = "Recommendations have been retrieved"
+)
@@ -0,0 +1,9 @@
+package constants
+
+const (
+	ReviewCreatedSuccess    = "Review has been created"
+	ReviewUpdatedSuccess    = "Review has been updated"
+	ReviewDeletedSuccess    = "Review has been deleted"
+	ReviewModeratedSuccess  = "Review has been moderated"
+	ReviewsRetrievedSuccess = "Reviews have been retrieved"
+)
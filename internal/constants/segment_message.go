@@ -0,0 +1,9 @@
+package constants
+
+const (
+	SegmentCreatedSuccess    = "Segment created successfully"
+	SegmentUpdatedSuccess    = "Segment updated successfully"
+	SegmentDeletedSuccess    = "Segment deleted successfully"
+	SegmentRetrievedSuccess  = "Segment retrieved successfully"
+	SegmentsRetrievedSuccess = "Segments retrieved successfully"
+)
@@ -0,0 +1,7 @@
+package constants
+
+const (
+	TaxRegionSetSuccess        = "Tax region set successfully"
+	TaxRegionDeletedSuccess    = "Tax region deleted successfully"
+	TaxRegionsRetrievedSuccess = "Tax regions retrieved successfully"
+)
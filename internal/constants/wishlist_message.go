@@ -0,0 +1,12 @@
+package constants
+
+const (
+	WishlistItemAddedSuccess   = "Product has been added to your wishlist"
+	WishlistItemUpdatedSuccess = "Wishlist notification preferences have been updated"
+	WishlistItemRemovedSuccess = "Product has been removed from your wishlist"
+	WishlistItemMovedSuccess   = "Product has been moved to your cart"
+	WishlistRetrievedSuccess   = "Wishlist has been retrieved"
+
+	NotificationsRetrievedSuccess = "Notifications have been retrieved"
+	NotificationReadSuccess       = "Notification has been marked as read"
+)
@@ -2,26 +2,78 @@ package handler
 
 import (
 	"net/http"
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
 	"play-to-win-api/internal/delivery/http/response"
 	"play-to-win-api/internal/domain"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type DiscountHandler struct {
 	BaseHandler
+	cartUseCase            domain.CartUseCase
 	cartItemUseCase        domain.CartItemUseCase
 	appliedDiscountUseCase domain.AppliedDiscountUseCase
+	discountRuleUseCase    domain.DiscountRuleUseCase
+	taxUseCase             domain.TaxUseCase
 }
 
-func NewDiscountHandler(cartItemUC domain.CartItemUseCase, appliedDiscountUC domain.AppliedDiscountUseCase) *DiscountHandler {
+func NewDiscountHandler(cartUC domain.CartUseCase, cartItemUC domain.CartItemUseCase, appliedDiscountUC domain.AppliedDiscountUseCase, discountRuleUC domain.DiscountRuleUseCase, taxUC domain.TaxUseCase) *DiscountHandler {
 	return &DiscountHandler{
+		cartUseCase:            cartUC,
 		cartItemUseCase:        cartItemUC,
 		appliedDiscountUseCase: appliedDiscountUC,
+		discountRuleUseCase:    discountRuleUC,
+		taxUseCase:             taxUC,
 	}
 }
 
+// CalculateCampaignDiscount applies the rules of every campaign running right
+// now to the cart, as seen by the cart's owner, and taxes what is left.
+func (h *DiscountHandler) CalculateCampaignDiscount(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	cart, err := h.cartUseCase.GetByID(c.Request().Context(), c.Param("cart_id"))
+	if err == domain.ErrInvalidCartID {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	if err != nil || (claims.Role != "admin" && cart.User.ID.Hex() != claims.UserID) {
+		return response.ErrorResponse(c, http.StatusNotFound, domain.ErrCartNotFound.Error())
+	}
+
+	cartItems, err := h.cartItemUseCase.GetByCartID(c.Request().Context(), cart.ID.Hex())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	evaluation, err := h.discountRuleUseCase.Evaluate(c.Request().Context(), cart.User.ID, cart.Currency, cartItems, time.Now())
+	if err != nil {
+		switch err {
+		case domain.ErrEmptyCart:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	tax, err := h.taxUseCase.Calculate(c.Request().Context(), cart.TaxRegion, cartItems, evaluation.Lines)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+	evaluation.Tax = tax
+	evaluation.Total += tax.Added()
+
+	return response.NewResponse(c, http.StatusOK, "Discount calculated successfully", evaluation)
+}
+
 func (h *DiscountHandler) CalculateFixedAmount(c echo.Context) error {
 	cartID := c.Param("cart_id")
 	amount := c.QueryParam("amount")
@@ -31,7 +83,9 @@ func (h *DiscountHandler) CalculateFixedAmount(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
-	finalPrice, err := h.appliedDiscountUseCase.CalculateFixedAmountDiscount(c.Request().Context(), cartItems, parseFloat(amount))
+	finalPrice, _, err := h.discounted(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculateFixedAmountDiscount(c.Request().Context(), items, parseMoney(amount))
+	})
 
 	if err != nil {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -51,7 +105,9 @@ func (h *DiscountHandler) CalculatePercentage(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
-	finalPrice, err := h.appliedDiscountUseCase.CalculatePercentageDiscount(c.Request().Context(), cartItems, parseFloat(percentage))
+	finalPrice, _, err := h.discounted(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculatePercentageDiscount(c.Request().Context(), items, parseFloat(percentage))
+	})
 	if err != nil {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
@@ -71,7 +127,32 @@ func (h *DiscountHandler) CalculateCategoryDiscount(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
-	finalPrice, err := h.appliedDiscountUseCase.CalculateCategoryDiscount(c.Request().Context(), cartItems, category, parseFloat(percentage))
+	finalPrice, _, err := h.discounted(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculateCategoryDiscount(c.Request().Context(), items, category, parseFloat(percentage))
+	})
+
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, "Discount calculated successfully", map[string]interface{}{
+		"final_price": finalPrice,
+	})
+}
+
+func (h *DiscountHandler) CalculateVariantDiscount(c echo.Context) error {
+	cartID := c.Param("cart_id")
+	sku := c.QueryParam("sku")
+	percentage := c.QueryParam("percentage")
+
+	cartItems, err := h.cartItemUseCase.GetByCartID(c.Request().Context(), cartID)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	finalPrice, _, err := h.discounted(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculateVariantDiscount(c.Request().Context(), items, sku, parseFloat(percentage))
+	})
 
 	if err != nil {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -91,7 +172,9 @@ func (h *DiscountHandler) CalculatePointsDiscount(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
-	finalPrice, err := h.appliedDiscountUseCase.CalculatePointsDiscount(c.Request().Context(), cartItems, parseInt(points))
+	finalPrice, _, err := h.discounted(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculatePointsDiscount(c.Request().Context(), items, parseInt(points))
+	})
 
 	if err != nil {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -112,22 +195,139 @@ func (h *DiscountHandler) CalculateSpecialDiscount(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
-	finalPrice, err := h.appliedDiscountUseCase.CalculateSpecialDiscount(c.Request().Context(), cartItems, parseFloat(threshold), parseFloat(discount))
+	finalPrice, _, err := h.discounted(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculateSpecialDiscount(c.Request().Context(), items, parseMoney(threshold), parseMoney(discount))
+	})
+
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, "Discount calculated successfully", map[string]interface{}{
+		"final_price": finalPrice,
+	})
+}
+
+// CalculateBuyXGetYDiscount covers BOGO too: leave buy and get unset for buy
+// one get one free.
+func (h *DiscountHandler) CalculateBuyXGetYDiscount(c echo.Context) error {
+	buyProductIDs, err := parseObjectIDs(c.QueryParam("buy_product_ids"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidProductID.Error())
+	}
+	getProductIDs, err := parseObjectIDs(c.QueryParam("get_product_ids"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidProductID.Error())
+	}
+	rule := domain.DiscountRule{
+		DiscuntType:   domain.DiscountTypeBuyXGetY,
+		BuyQuantity:   parseInt(c.QueryParam("buy")),
+		BuyProductIDs: buyProductIDs,
+		BuyCategory:   c.QueryParam("buy_category"),
+		GetQuantity:   parseInt(c.QueryParam("get")),
+		GetProductIDs: getProductIDs,
+		GetCategory:   c.QueryParam("get_category"),
+		Percentage:    parseFloat(c.QueryParam("percentage")),
+	}
+	if rule.BuyQuantity == 0 && rule.GetQuantity == 0 {
+		rule.DiscuntType = domain.DiscountTypeBOGO
+	}
 
+	cartItems, err := h.cartItemUseCase.GetByCartID(c.Request().Context(), c.Param("cart_id"))
 	if err != nil {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
+	return h.allocated(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculateBuyXGetYDiscount(c.Request().Context(), items, rule.BuyXGetY())
+	})
+}
+
+func (h *DiscountHandler) CalculateBundleDiscount(c echo.Context) error {
+	productIDs, err := parseObjectIDs(c.QueryParam("product_ids"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidProductID.Error())
+	}
+	bundle := domain.Bundle{ProductIDs: productIDs, Price: parseMoney(c.QueryParam("price"))}
+
+	cartItems, err := h.cartItemUseCase.GetByCartID(c.Request().Context(), c.Param("cart_id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return h.allocated(c, cartItems, func(items []domain.CartItem) ([]domain.DiscountAllocation, error) {
+		return h.appliedDiscountUseCase.CalculateBundleDiscount(c.Request().Context(), items, bundle)
+	})
+}
+
+// allocated responds with the final price and how the discount falls on each
+// line.
+func (h *DiscountHandler) allocated(c echo.Context, cartItems []domain.CartItem, calc func([]domain.CartItem) ([]domain.DiscountAllocation, error)) error {
+	finalPrice, allocations, err := h.discounted(c, cartItems, calc)
+	if err != nil {
+		switch err {
+		case domain.ErrEmptyCart, domain.ErrInvalidOffer, domain.ErrInvalidBundle:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
 	return response.NewResponse(c, http.StatusOK, "Discount calculated successfully", map[string]interface{}{
 		"final_price": finalPrice,
+		"items":       allocations,
 	})
 }
 
+// discounted runs calc over the cart and returns the final price with the
+// discount's allocation to the lines. With ?exclude_sale_items=true, items
+// already on sale keep their sale price and are not discounted again.
+func (h *DiscountHandler) discounted(c echo.Context, cartItems []domain.CartItem, calc func([]domain.CartItem) ([]domain.DiscountAllocation, error)) (domain.Money, []domain.DiscountAllocation, error) {
+	items := cartItems
+	if c.QueryParam("exclude_sale_items") == "true" {
+		items, _ = domain.SplitSaleItems(cartItems)
+	}
+
+	var allocations []domain.DiscountAllocation
+	if len(items) > 0 || len(cartItems) == 0 {
+		var err error
+		if allocations, err = calc(items); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	var total domain.Money
+	for _, item := range cartItems {
+		total += item.TotalPrice
+	}
+	return total - domain.SumAllocations(allocations), allocations, nil
+}
+
 func parseFloat(s string) float64 {
 	f, _ := strconv.ParseFloat(s, 64)
 	return f
 }
 
+func parseMoney(s string) domain.Money {
+	m, _ := domain.ParseMoney(s)
+	return m
+}
+
+func parseObjectIDs(s string) ([]primitive.ObjectID, error) {
+	var ids []primitive.ObjectID
+	for _, hex := range strings.Split(s, ",") {
+		if hex = strings.TrimSpace(hex); hex == "" {
+			continue
+		}
+		id, err := primitive.ObjectIDFromHex(hex)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func parseInt(s string) int {
 	i, _ := strconv.Atoi(s)
 	return i
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+func archiveErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidProductID, domain.ErrInvalidCategoryID, domain.ErrInvalidCampaignID:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrProductNotFound, domain.ErrCategoryNotFound, domain.ErrCampaignNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
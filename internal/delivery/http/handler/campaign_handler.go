@@ -1,8 +1,8 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"time"
 
 	"play-to-win-api/internal/constants"
 	"play-to-win-api/internal/delivery/http/response"
@@ -15,13 +15,15 @@ import (
 
 type CampaignHandler struct {
 	BaseHandler
-	campaignUseCase domain.CampaignUseCase
+	campaignUseCase   domain.CampaignUseCase
+	simulationUseCase domain.CampaignSimulationUseCase
 }
 
-func NewCampaignHandler(uc domain.CampaignUseCase) CampaignHandler {
+func NewCampaignHandler(uc domain.CampaignUseCase, simulationUC domain.CampaignSimulationUseCase) CampaignHandler {
 	return CampaignHandler{
-		BaseHandler:     BaseHandler{validator: validator.NewValidator()},
-		campaignUseCase: uc,
+		BaseHandler:       BaseHandler{validator: validator.NewValidator()},
+		campaignUseCase:   uc,
+		simulationUseCase: simulationUC,
 	}
 }
 
@@ -31,12 +33,8 @@ func (h *CampaignHandler) Create(c echo.Context) error {
 		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
 	}
 
-	campaign.IsActive = true
-	campaign.StartDate = time.Now()
-	campaign.EndDate = time.Now().AddDate(0, 0, 7)
-
 	if err := h.campaignUseCase.Create(c.Request().Context(), &campaign); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return campaignErrorResponse(c, err, campaign.Conflicts)
 	}
 
 	return response.NewResponse(c, http.StatusCreated, constants.CampaignCreatedSuccess, campaign)
@@ -53,12 +51,17 @@ func (h *CampaignHandler) GetByID(c echo.Context) error {
 }
 
 func (h *CampaignHandler) GetAll(c echo.Context) error {
-	campaigns, err := h.campaignUseCase.GetAll(c.Request().Context())
+	query, err := parseListQuery(c)
 	if err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
 	}
 
-	return response.NewResponse(c, http.StatusOK, constants.CampaignsRetrievedSuccess, campaigns)
+	campaigns, pagination, err := h.campaignUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.CampaignsRetrievedSuccess, campaigns, pagination)
 }
 
 func (h *CampaignHandler) Update(c echo.Context) error {
@@ -76,7 +79,7 @@ func (h *CampaignHandler) Update(c echo.Context) error {
 
 	campaign.ID = objectID
 	if err := h.campaignUseCase.Update(c.Request().Context(), &campaign); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return campaignErrorResponse(c, err, campaign.Conflicts)
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.CampaignUpdatedSuccess, campaign)
@@ -85,8 +88,103 @@ func (h *CampaignHandler) Update(c echo.Context) error {
 func (h *CampaignHandler) Delete(c echo.Context) error {
 	id := c.Param("id")
 	if err := h.campaignUseCase.Delete(c.Request().Context(), id); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return archiveErrorResponse(c, err)
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.CampaignDeletedSuccess, nil)
 }
+
+func (h *CampaignHandler) Restore(c echo.Context) error {
+	campaign, err := h.campaignUseCase.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return archiveErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CampaignRestoredSuccess, campaign)
+}
+
+func (h *CampaignHandler) GetArchived(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	campaigns, pagination, err := h.campaignUseCase.GetArchived(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.CampaignsArchivedSuccess, campaigns, pagination)
+}
+
+func (h *CampaignHandler) GetStats(c echo.Context) error {
+	var query domain.CampaignStatsQuery
+	var err error
+	if query.From, err = optionalTime(c.QueryParam("from")); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if query.To, err = optionalTime(c.QueryParam("to")); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	query.TopProducts = parseInt(c.QueryParam("top"))
+
+	stats, err := h.campaignUseCase.GetStats(c.Request().Context(), c.Param("id"), query)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidCampaignID, domain.ErrInvalidStatsRange:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrCampaignNotFound:
+			return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CampaignStatsRetrievedSuccess, stats)
+}
+
+func (h *CampaignHandler) Simulate(c echo.Context) error {
+	var request domain.CampaignSimulationRequest
+	if err := c.Bind(&request); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+
+	simulation, err := h.simulationUseCase.Simulate(c.Request().Context(), request)
+	if errors.Is(err, domain.ErrInvalidCondition) {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidSimulation, domain.ErrInvalidSimulationRange, domain.ErrInvalidCampaignLimits, domain.ErrInvalidTiers, domain.ErrInvalidCurrency:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CampaignSimulatedSuccess, simulation)
+}
+
+func (h *CampaignHandler) GetConflicts(c echo.Context) error {
+	conflicts, err := h.campaignUseCase.GetConflicts(c.Request().Context())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CampaignConflictsRetrievedSuccess, conflicts)
+}
+
+// campaignErrorResponse reports blocking conflicts alongside a 409 so the
+// caller can see which campaigns are in the way.
+func campaignErrorResponse(c echo.Context, err error, conflicts []domain.CampaignConflict) error {
+	switch err {
+	case domain.ErrCampaignConflict:
+		return response.NewResponse(c, http.StatusConflict, err.Error(), conflicts)
+	case domain.ErrInvalidCampaignWindow, domain.ErrInvalidCampaignLimits:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrCampaignNotFound, domain.ErrSegmentNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
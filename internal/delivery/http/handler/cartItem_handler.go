@@ -10,6 +10,7 @@ import (
 	"play-to-win-api/pkg/validator"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type CartItemHandler struct {
@@ -34,7 +35,13 @@ func (h *CartItemHandler) Create(c echo.Context) error {
 	cartItem.UpdatedAt = time.Now()
 
 	if err := h.cartItemUseCase.Create(c.Request().Context(), &cartItem); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		switch err {
+		case domain.ErrVariantRequired, domain.ErrVariantNotFound, domain.ErrInsufficientStock, domain.ErrProductNotFound,
+			domain.ErrCartNotFound, domain.ErrUnsupportedCurrency:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
 	}
 
 	return response.NewResponse(c, http.StatusCreated, constants.CartItemCreatedSuccess, cartItem)
@@ -61,12 +68,17 @@ func (h *CartItemHandler) GetByID(c echo.Context) error {
 }
 
 func (h *CartItemHandler) GetAll(c echo.Context) error {
-	cartItems, err := h.cartItemUseCase.GetAll(c.Request().Context())
+	query, err := parseListQuery(c)
 	if err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
 	}
 
-	return response.NewResponse(c, http.StatusOK, constants.CartItemRetrievedSuccess, cartItems)
+	cartItems, pagination, err := h.cartItemUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.CartItemRetrievedSuccess, cartItems, pagination)
 }
 
 func (h *CartItemHandler) Update(c echo.Context) error {
@@ -74,9 +86,22 @@ func (h *CartItemHandler) Update(c echo.Context) error {
 	if err := c.Bind(&cartItem); err != nil {
 		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
 	}
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidCartItemID.Error())
+	}
+	cartItem.ID = id
 
 	if err := h.cartItemUseCase.Update(c.Request().Context(), &cartItem); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		switch err {
+		case domain.ErrCartItemNotFound:
+			return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case domain.ErrCartItemProductChanged, domain.ErrVariantRequired, domain.ErrVariantNotFound, domain.ErrInsufficientStock,
+			domain.ErrProductNotFound, domain.ErrCartNotFound, domain.ErrUnsupportedCurrency:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.CartItemUpdatedSuccess, cartItem)
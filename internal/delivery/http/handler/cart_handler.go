@@ -48,7 +48,12 @@ func (h *CartHandler) Create(c echo.Context) error {
 	cart.UpdatedAt = time.Now()
 
 	if err := h.cartUseCase.Create(c.Request().Context(), &cart); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		switch err {
+		case domain.ErrInvalidCurrency, domain.ErrUnsupportedCurrency, domain.ErrUnsupportedTaxRegion:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
 	}
 
 	return response.NewResponse(c, http.StatusCreated, constants.CartCreatedSuccess, cart)
@@ -85,12 +90,17 @@ func (h *CartHandler) GetByUserID(c echo.Context) error {
 }
 
 func (h *CartHandler) GetAll(c echo.Context) error {
-	carts, err := h.cartUseCase.GetAll(c.Request().Context())
+	query, err := parseListQuery(c)
 	if err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	carts, pagination, err := h.cartUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
 	}
 
-	return response.NewResponse(c, http.StatusOK, constants.CartRetrievedSuccess, carts)
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.CartRetrievedSuccess, carts, pagination)
 }
 
 func (h *CartHandler) Update(c echo.Context) error {
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CatalogTransferHandler struct {
+	catalogTransferUseCase domain.CatalogTransferUseCase
+}
+
+func NewCatalogTransferHandler(uc domain.CatalogTransferUseCase) CatalogTransferHandler {
+	return CatalogTransferHandler{catalogTransferUseCase: uc}
+}
+
+func (h *CatalogTransferHandler) ImportProducts(c echo.Context) error {
+	dryRun, err := optionalBool(c.QueryParam("dry_run"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+
+	body, format, err := importSource(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	defer body.Close()
+
+	result, err := h.catalogTransferUseCase.ImportProducts(c.Request().Context(), format, body, dryRun != nil && *dryRun)
+	if err != nil {
+		switch err {
+		case domain.ErrUnsupportedFormat, domain.ErrInvalidImportFile:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	message := constants.ProductsImportedSuccess
+	if result.DryRun {
+		message = constants.ProductsImportValidatedSuccess
+	}
+	return response.NewResponse(c, http.StatusOK, message, result)
+}
+
+func (h *CatalogTransferHandler) ExportProducts(c echo.Context) error {
+	return h.export(c, "products", h.catalogTransferUseCase.ExportProducts)
+}
+
+func (h *CatalogTransferHandler) ExportCategories(c echo.Context) error {
+	return h.export(c, "categories", h.catalogTransferUseCase.ExportCategories)
+}
+
+func (h *CatalogTransferHandler) export(c echo.Context, name string, run func(ctx context.Context, format domain.TransferFormat, w io.Writer) error) error {
+	format, err := domain.ParseTransferFormat(defaultString(c.QueryParam("format"), string(domain.TransferFormatCSV)))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format)
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, format.ContentType())
+	res.Header().Set(echo.HeaderContentDisposition, "attachment; filename="+strconv.Quote(filename))
+	res.WriteHeader(http.StatusOK)
+
+	return run(c.Request().Context(), format, res)
+}
+
+// importSource accepts either a multipart "file" upload or the raw request
+// body, taking the format from ?format=, the file extension or Content-Type.
+func importSource(c echo.Context) (io.ReadCloser, domain.TransferFormat, error) {
+	formatHint := c.QueryParam("format")
+
+	if file, err := c.FormFile("file"); err == nil {
+		if formatHint == "" {
+			formatHint = filepath.Ext(file.Filename)
+			if len(formatHint) > 0 {
+				formatHint = formatHint[1:]
+			}
+		}
+		format, err := domain.ParseTransferFormat(formatHint)
+		if err != nil {
+			return nil, "", err
+		}
+		src, err := file.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		return src, format, nil
+	}
+
+	if formatHint == "" {
+		formatHint = c.Request().Header.Get(echo.HeaderContentType)
+	}
+	format, err := domain.ParseTransferFormat(formatHint)
+	if err != nil {
+		return nil, "", err
+	}
+	return c.Request().Body, format, nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
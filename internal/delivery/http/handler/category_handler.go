@@ -30,7 +30,12 @@ func (h *CategoryHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.categoryUseCase.Create(c.Request().Context(), &category); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		switch err {
+		case domain.ErrParentCategoryNotFound:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return slugErrorResponse(c, err)
+		}
 	}
 
 	return response.NewResponse(c, http.StatusCreated, constants.CategoryCreatedSuccess, category)
@@ -46,13 +51,63 @@ func (h *CategoryHandler) GetByID(c echo.Context) error {
 	return response.NewResponse(c, http.StatusOK, constants.CategoryRetrievedSuccess, category)
 }
 
+func (h *CategoryHandler) GetBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+	category, err := h.categoryUseCase.GetBySlug(c.Request().Context(), slug)
+	if err != nil {
+		if err == domain.ErrCategoryNotFound {
+			return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+	if category.Slug != slug {
+		return redirectToSlug(c, category.Slug)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CategoryRetrievedSuccess, category)
+}
+
 func (h *CategoryHandler) GetAll(c echo.Context) error {
-	categories, err := h.categoryUseCase.GetAll(c.Request().Context())
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	categories, pagination, err := h.categoryUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.CategoriesRetrievedSuccess, categories, pagination)
+}
+
+func (h *CategoryHandler) GetTree(c echo.Context) error {
+	tree, err := h.categoryUseCase.GetTree(c.Request().Context())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CategoryTreeRetrievedSuccess, tree)
+}
+
+func (h *CategoryHandler) GetSubtree(c echo.Context) error {
+	id := c.Param("id")
+	subtree, err := h.categoryUseCase.GetSubtree(c.Request().Context(), id)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CategoryTreeRetrievedSuccess, subtree)
+}
+
+func (h *CategoryHandler) GetBreadcrumbs(c echo.Context) error {
+	id := c.Param("id")
+	breadcrumbs, err := h.categoryUseCase.GetBreadcrumbs(c.Request().Context(), id)
 	if err != nil {
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
 
-	return response.NewResponse(c, http.StatusOK, constants.CategoriesRetrievedSuccess, categories)
+	return response.NewResponse(c, http.StatusOK, constants.CategoryBreadcrumbsRetrievedSuccess, breadcrumbs)
 }
 
 func (h *CategoryHandler) Update(c echo.Context) error {
@@ -69,7 +124,12 @@ func (h *CategoryHandler) Update(c echo.Context) error {
 
 	category.ID = objectID
 	if err := h.categoryUseCase.Update(c.Request().Context(), &category); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		switch err {
+		case domain.ErrCategoryCycle, domain.ErrParentCategoryNotFound:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return slugErrorResponse(c, err)
+		}
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.CategoryUpdatedSuccess, category)
@@ -78,8 +138,36 @@ func (h *CategoryHandler) Update(c echo.Context) error {
 func (h *CategoryHandler) Delete(c echo.Context) error {
 	id := c.Param("id")
 	if err := h.categoryUseCase.Delete(c.Request().Context(), id); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		switch err {
+		case domain.ErrCategoryHasChildren:
+			return response.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			return archiveErrorResponse(c, err)
+		}
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.CategoryDeletedSuccess, nil)
 }
+
+func (h *CategoryHandler) Restore(c echo.Context) error {
+	category, err := h.categoryUseCase.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return archiveErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.CategoryRestoredSuccess, category)
+}
+
+func (h *CategoryHandler) GetArchived(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	categories, pagination, err := h.categoryUseCase.GetArchived(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.CategoriesArchivedSuccess, categories, pagination)
+}
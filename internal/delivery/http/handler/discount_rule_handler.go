@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"play-to-win-api/internal/constants"
@@ -31,7 +32,7 @@ func (h *DiscountRuleHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.discountRuleUseCase.Create(c.Request().Context(), &discountRule); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return discountRuleErrorResponse(c, err, discountRule.Conflicts)
 	}
 
 	return response.NewResponse(c, http.StatusCreated, constants.DiscountRuleCreatedSuccess, discountRule)
@@ -48,12 +49,17 @@ func (h *DiscountRuleHandler) GetByID(c echo.Context) error {
 }
 
 func (h *DiscountRuleHandler) GetAll(c echo.Context) error {
-	discountRules, err := h.discountRuleUseCase.GetAll(c.Request().Context())
+	query, err := parseListQuery(c)
 	if err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	discountRules, pagination, err := h.discountRuleUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
 	}
 
-	return response.NewResponse(c, http.StatusOK, constants.DiscountRulesRetrievedSuccess, discountRules)
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.DiscountRulesRetrievedSuccess, discountRules, pagination)
 }
 
 func (h *DiscountRuleHandler) Update(c echo.Context) error {
@@ -65,7 +71,7 @@ func (h *DiscountRuleHandler) Update(c echo.Context) error {
 
 	discountRule.ID, _ = primitive.ObjectIDFromHex(id)
 	if err := h.discountRuleUseCase.Update(c.Request().Context(), &discountRule); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return discountRuleErrorResponse(c, err, discountRule.Conflicts)
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.DiscountRuleUpdatedSuccess, discountRule)
@@ -79,3 +85,19 @@ func (h *DiscountRuleHandler) Delete(c echo.Context) error {
 
 	return response.NewResponse(c, http.StatusOK, constants.DiscountRuleDeletedSuccess, nil)
 }
+
+func discountRuleErrorResponse(c echo.Context, err error, conflicts []domain.CampaignConflict) error {
+	if errors.Is(err, domain.ErrInvalidCondition) {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	switch err {
+	case domain.ErrCampaignConflict:
+		return response.NewResponse(c, http.StatusConflict, err.Error(), conflicts)
+	case domain.ErrInvalidTiers, domain.ErrInvalidCurrency, domain.ErrInvalidDiscountType, domain.ErrInvalidOffer, domain.ErrInvalidBundle:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrCampaignNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
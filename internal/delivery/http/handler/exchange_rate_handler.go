@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ExchangeRateHandler struct {
+	BaseHandler
+	exchangeRateUseCase domain.ExchangeRateUseCase
+}
+
+func NewExchangeRateHandler(uc domain.ExchangeRateUseCase) ExchangeRateHandler {
+	return ExchangeRateHandler{
+		BaseHandler:         BaseHandler{validator: validator.NewValidator()},
+		exchangeRateUseCase: uc,
+	}
+}
+
+func (h *ExchangeRateHandler) GetAll(c echo.Context) error {
+	rates, err := h.exchangeRateUseCase.GetAll(c.Request().Context())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ExchangeRatesRetrievedSuccess, map[string]interface{}{
+		"base":  domain.BaseCurrency,
+		"rates": rates,
+	})
+}
+
+// Set creates or replaces the rate for the currency in the path, given as
+// units of that currency per unit of the base currency.
+func (h *ExchangeRateHandler) Set(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var rate domain.ExchangeRate
+	if err := c.Bind(&rate); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&rate); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	rate.Currency = domain.Currency(c.Param("currency"))
+	rate.UpdatedBy = claims.Email
+	if err := h.exchangeRateUseCase.Set(c.Request().Context(), &rate); err != nil {
+		return exchangeRateErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ExchangeRateSetSuccess, rate)
+}
+
+func (h *ExchangeRateHandler) Delete(c echo.Context) error {
+	if err := h.exchangeRateUseCase.Delete(c.Request().Context(), c.Param("currency")); err != nil {
+		return exchangeRateErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ExchangeRateDeletedSuccess, nil)
+}
+
+func exchangeRateErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidCurrency, domain.ErrBaseCurrencyRate:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrExchangeRateNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
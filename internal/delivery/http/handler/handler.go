@@ -10,15 +10,27 @@ import (
 )
 
 type Handlers struct {
-	Category     CategoryHandler
-	Auth         AuthHandler
-	AuthMW       *middleware.AuthMiddleware
-	Product      ProductHandler
-	Campaign     CampaignHandler
-	Cart         CartHandler
-	CartItem     CartItemHandler
-	DiscountRule DiscountRuleHandler
-	Discount     *DiscountHandler
+	Category        CategoryHandler
+	Auth            AuthHandler
+	AuthMW          *middleware.AuthMiddleware
+	Product         ProductHandler
+	ProductImage    ProductImageHandler
+	CatalogTransfer CatalogTransferHandler
+	Review          ReviewHandler
+	Inventory       InventoryHandler
+	Pricing         PricingHandler
+	Campaign        CampaignHandler
+	Cart            CartHandler
+	CartItem        CartItemHandler
+	Wishlist        WishlistHandler
+	Notification    NotificationHandler
+	Recommendation  RecommendationHandler
+	Order           OrderHandler
+	DiscountRule    DiscountRuleHandler
+	Segment         SegmentHandler
+	ExchangeRate    ExchangeRateHandler
+	Tax             TaxHandler
+	Discount        *DiscountHandler
 }
 
 func NewHandlers(e *echo.Echo, categoryUseCase domain.CategoryUseCase, authUseCase domain.AuthUseCase, productUseCase domain.ProductUseCase, campaignUseCase domain.CampaignUseCase, cartUseCase domain.CartUseCase, cartItemUseCase domain.CartItemUseCase,
@@ -29,7 +41,7 @@ func NewHandlers(e *echo.Echo, categoryUseCase domain.CategoryUseCase, authUseCa
 		Category: NewCategoryHandler(categoryUseCase),
 		Auth:     NewAuthHandler(authUseCase, validator),
 		Product:  NewProductHandler(productUseCase),
-		Campaign: NewCampaignHandler(campaignUseCase),
+		Campaign: NewCampaignHandler(campaignUseCase, nil),
 		Cart:     NewCartHandler(cartUseCase, authUseCase),
 		CartItem: NewCartItemHandler(cartItemUseCase),
 	}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type InventoryHandler struct {
+	BaseHandler
+	inventoryUseCase domain.InventoryUseCase
+}
+
+func NewInventoryHandler(uc domain.InventoryUseCase) InventoryHandler {
+	return InventoryHandler{
+		BaseHandler:      BaseHandler{validator: validator.NewValidator()},
+		inventoryUseCase: uc,
+	}
+}
+
+func (h *InventoryHandler) AdjustStock(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var adjustment domain.StockAdjustment
+	if err := c.Bind(&adjustment); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&adjustment); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	actor := domain.Actor{ID: claims.UserID, Email: claims.Email}
+	movement, err := h.inventoryUseCase.Adjust(c.Request().Context(), c.Param("id"), actor, adjustment)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidProductID, domain.ErrInvalidVariantID, domain.ErrInvalidMovementType,
+			domain.ErrInvalidStockQuantity, domain.ErrVariantRequired:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrProductNotFound, domain.ErrVariantNotFound:
+			return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case domain.ErrInsufficientStock, domain.ErrStockChanged:
+			return response.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.StockAdjustedSuccess, movement)
+}
+
+func (h *InventoryHandler) GetMovements(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	movements, pagination, err := h.inventoryUseCase.GetMovements(c.Request().Context(), c.Param("id"), query)
+	if err != nil {
+		if err == domain.ErrInvalidProductID {
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.StockMovementsRetrievedSuccess, movements, pagination)
+}
+
+func (h *InventoryHandler) GetLowStock(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	products, pagination, err := h.inventoryUseCase.GetLowStock(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.LowStockRetrievedSuccess, products, pagination)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func parseListQuery(c echo.Context) (domain.ListQuery, error) {
+	var query domain.ListQuery
+	var err error
+
+	if query.Page, err = optionalInt(c.QueryParam("page")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	if query.Limit, err = optionalInt(c.QueryParam("limit")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	query.Cursor = c.QueryParam("cursor")
+
+	for _, field := range strings.Split(c.QueryParam("sort"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		query.Sort = append(query.Sort, domain.SortField{
+			Field: strings.TrimPrefix(field, "-"),
+			Desc:  strings.HasPrefix(field, "-"),
+		})
+	}
+
+	filter := &query.Filter
+	filter.Category = c.QueryParam("category")
+	filter.CategoryID = c.QueryParam("category_id")
+	filter.Status = c.QueryParam("status")
+	filter.Type = c.QueryParam("type")
+	if filter.MinPrice, err = optionalMoney(c.QueryParam("min_price")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	if filter.MaxPrice, err = optionalMoney(c.QueryParam("max_price")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	if filter.InStock, err = optionalBool(c.QueryParam("in_stock")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	if filter.IncludeDescendants, err = optionalBool(c.QueryParam("include_descendants")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	if filter.CreatedFrom, err = optionalTime(c.QueryParam("created_from")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+	if filter.CreatedTo, err = optionalTime(c.QueryParam("created_to")); err != nil {
+		return query, domain.ErrInvalidListQuery
+	}
+
+	return query, nil
+}
+
+func listErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidListQuery, domain.ErrInvalidSortField, domain.ErrInvalidCursor, domain.ErrInvalidCategoryID:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func optionalInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func optionalMoney(s string) (*domain.Money, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m, err := domain.ParseMoney(s)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func optionalBool(s string) (*bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func optionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
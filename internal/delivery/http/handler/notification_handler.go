@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler struct {
+	BaseHandler
+	notificationUseCase domain.NotificationUseCase
+}
+
+func NewNotificationHandler(uc domain.NotificationUseCase) NotificationHandler {
+	return NotificationHandler{
+		BaseHandler:         BaseHandler{validator: validator.NewValidator()},
+		notificationUseCase: uc,
+	}
+}
+
+func (h *NotificationHandler) GetAll(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	notifications, pagination, err := h.notificationUseCase.GetByUserID(c.Request().Context(), claims.UserID, query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.NotificationsRetrievedSuccess, notifications, pagination)
+}
+
+func (h *NotificationHandler) MarkRead(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	if err := h.notificationUseCase.MarkRead(c.Request().Context(), claims.UserID, c.Param("id")); err != nil {
+		switch err {
+		case domain.ErrInvalidNotificationID, domain.ErrInvalidUserID:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case domain.ErrNotificationNotFound:
+			return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.NotificationReadSuccess, nil)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler struct {
+	BaseHandler
+	orderUseCase domain.OrderUseCase
+}
+
+func NewOrderHandler(uc domain.OrderUseCase) OrderHandler {
+	return OrderHandler{
+		BaseHandler:  BaseHandler{validator: validator.NewValidator()},
+		orderUseCase: uc,
+	}
+}
+
+func (h *OrderHandler) Checkout(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	actor := domain.Actor{ID: claims.UserID, Email: claims.Email}
+	order, err := h.orderUseCase.Checkout(c.Request().Context(), actor, c.Param("id"))
+	if err != nil {
+		return orderErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.OrderPlacedSuccess, order)
+}
+
+func (h *OrderHandler) GetAll(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	orders, pagination, err := h.orderUseCase.GetByUserID(c.Request().Context(), claims.UserID, query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.OrdersRetrievedSuccess, orders, pagination)
+}
+
+func (h *OrderHandler) GetByID(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	order, err := h.orderUseCase.GetByID(c.Request().Context(), claims.UserID, c.Param("id"))
+	if err != nil {
+		return orderErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.OrderRetrievedSuccess, order)
+}
+
+func orderErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidUserID, domain.ErrInvalidCartID, domain.ErrInvalidOrderID, domain.ErrEmptyCart, domain.ErrVariantRequired:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrCartNotFound, domain.ErrOrderNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case domain.ErrInsufficientStock, domain.ErrProductNotFound, domain.ErrVariantNotFound:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
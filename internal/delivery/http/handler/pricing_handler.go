@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PricingHandler struct {
+	BaseHandler
+	pricingUseCase domain.PricingUseCase
+}
+
+func NewPricingHandler(uc domain.PricingUseCase) PricingHandler {
+	return PricingHandler{
+		BaseHandler:    BaseHandler{validator: validator.NewValidator()},
+		pricingUseCase: uc,
+	}
+}
+
+func (h *PricingHandler) Schedule(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var schedule domain.PriceSchedule
+	if err := c.Bind(&schedule); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&schedule); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	actor := domain.Actor{ID: claims.UserID, Email: claims.Email}
+	change, err := h.pricingUseCase.Schedule(c.Request().Context(), c.Param("id"), actor, schedule)
+	if err != nil {
+		return pricingErrorResponse(c, err)
+	}
+
+	if change.Status == domain.PriceChangeApplied {
+		return response.NewResponse(c, http.StatusCreated, constants.PriceAppliedSuccess, change)
+	}
+	return response.NewResponse(c, http.StatusCreated, constants.PriceScheduledSuccess, change)
+}
+
+func (h *PricingHandler) GetHistory(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	changes, pagination, err := h.pricingUseCase.GetHistory(c.Request().Context(), c.Param("id"), query)
+	if err != nil {
+		if err == domain.ErrInvalidProductID {
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.PriceHistoryRetrievedSuccess, changes, pagination)
+}
+
+func (h *PricingHandler) Cancel(c echo.Context) error {
+	if err := h.pricingUseCase.Cancel(c.Request().Context(), c.Param("id"), c.Param("change_id")); err != nil {
+		return pricingErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.PriceCancelledSuccess, nil)
+}
+
+func pricingErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidProductID, domain.ErrInvalidPriceChangeID:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrProductNotFound, domain.ErrPriceChangeNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case domain.ErrPriceChangeNotScheduled:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -31,7 +31,10 @@ func (h *ProductHandler) Create(c echo.Context) error {
 	}
 
 	if err := h.productUseCase.Create(c.Request().Context(), &product); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		if err == domain.ErrInvalidPriceList || err == domain.ErrInvalidTaxClass {
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return slugErrorResponse(c, err)
 	}
 
 	return response.NewResponse(c, http.StatusCreated, constants.ProductCreatedSuccess, product)
@@ -47,13 +50,92 @@ func (h *ProductHandler) GetByID(c echo.Context) error {
 	return response.NewResponse(c, http.StatusOK, constants.ProductRetrievedSuccess, product)
 }
 
-func (h *ProductHandler) GetAll(c echo.Context) error {
-	products, err := h.productUseCase.GetAll(c.Request().Context())
+func (h *ProductHandler) GetBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+	product, err := h.productUseCase.GetBySlug(c.Request().Context(), slug)
 	if err != nil {
+		if err == domain.ErrProductNotFound {
+			return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+		}
 		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
+	if product.Slug != slug {
+		return redirectToSlug(c, product.Slug)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductRetrievedSuccess, product)
+}
+
+func (h *ProductHandler) GetAll(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	products, pagination, err := h.productUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.ProductsRetrievedSuccess, products, pagination)
+}
+
+func (h *ProductHandler) Search(c echo.Context) error {
+	query := domain.ProductSearchQuery{
+		Query:      c.QueryParam("q"),
+		CategoryID: c.QueryParam("category_id"),
+		Limit:      parseInt(c.QueryParam("limit")),
+		Offset:     parseInt(c.QueryParam("offset")),
+	}
+
+	var err error
+	if query.MinPrice, err = optionalMoney(c.QueryParam("min_price")); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if query.MaxPrice, err = optionalMoney(c.QueryParam("max_price")); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+
+	result, err := h.productUseCase.Search(c.Request().Context(), query)
+	if err != nil {
+		switch err {
+		case domain.ErrEmptySearchQuery, domain.ErrInvalidCategoryID:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductsSearchedSuccess, result)
+}
+
+func (h *ProductHandler) Suggest(c echo.Context) error {
+	suggestions, err := h.productUseCase.Suggest(c.Request().Context(), c.QueryParam("q"), parseInt(c.QueryParam("limit")))
+	if err != nil {
+		switch err {
+		case domain.ErrEmptySearchQuery:
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+	}
 
-	return response.NewResponse(c, http.StatusOK, constants.ProductsRetrievedSuccess, products)
+	return response.NewResponse(c, http.StatusOK, constants.ProductSuggestionsSuccess, suggestions)
+}
+
+func (h *ProductHandler) GetByCategory(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	query.Filter.CategoryID = c.Param("id")
+
+	products, pagination, err := h.productUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.ProductsRetrievedSuccess, products, pagination)
 }
 
 func (h *ProductHandler) Update(c echo.Context) error {
@@ -71,17 +153,102 @@ func (h *ProductHandler) Update(c echo.Context) error {
 	product.ID = objectID
 
 	if err := h.productUseCase.Update(c.Request().Context(), &product); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		if err == domain.ErrInvalidPriceList || err == domain.ErrInvalidTaxClass {
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return slugErrorResponse(c, err)
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.ProductUpdatedSuccess, product)
 }
 
+func (h *ProductHandler) AddVariant(c echo.Context) error {
+	var variant domain.ProductVariant
+	if err := c.Bind(&variant); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&variant); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.productUseCase.AddVariant(c.Request().Context(), c.Param("id"), &variant); err != nil {
+		return variantErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.ProductVariantCreatedSuccess, variant)
+}
+
+func (h *ProductHandler) UpdateVariant(c echo.Context) error {
+	var variant domain.ProductVariant
+	if err := c.Bind(&variant); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&variant); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	variantID, err := primitive.ObjectIDFromHex(c.Param("variant_id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidVariantID.Error())
+	}
+
+	variant.ID = variantID
+	if err := h.productUseCase.UpdateVariant(c.Request().Context(), c.Param("id"), &variant); err != nil {
+		return variantErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductVariantUpdatedSuccess, variant)
+}
+
+func (h *ProductHandler) DeleteVariant(c echo.Context) error {
+	if err := h.productUseCase.DeleteVariant(c.Request().Context(), c.Param("id"), c.Param("variant_id")); err != nil {
+		return variantErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductVariantDeletedSuccess, nil)
+}
+
+func variantErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidProductID, domain.ErrInvalidVariantID:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrVariantNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case domain.ErrDuplicateSKU:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
+
 func (h *ProductHandler) Delete(c echo.Context) error {
 	id := c.Param("id")
 	if err := h.productUseCase.Delete(c.Request().Context(), id); err != nil {
-		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return archiveErrorResponse(c, err)
 	}
 
 	return response.NewResponse(c, http.StatusOK, constants.ProductDeletedSuccess, nil)
 }
+
+func (h *ProductHandler) Restore(c echo.Context) error {
+	product, err := h.productUseCase.Restore(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return archiveErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductRestoredSuccess, product)
+}
+
+func (h *ProductHandler) GetArchived(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	products, pagination, err := h.productUseCase.GetArchived(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.ProductsArchivedSuccess, products, pagination)
+}
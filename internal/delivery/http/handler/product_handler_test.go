@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listingProducts records the query GetAll was asked for.
+type listingProducts struct {
+	domain.ProductUseCase
+	query domain.ListQuery
+}
+
+func (uc *listingProducts) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	uc.query = query
+	return []domain.Product{}, &domain.Pagination{}, nil
+}
+
+func getByCategory(t *testing.T, target string) domain.ListFilter {
+	uc := &listingProducts{}
+	h := NewProductHandler(uc)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("64b7f0c2a1b2c3d4e5f60718")
+
+	require.NoError(t, h.GetByCategory(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "64b7f0c2a1b2c3d4e5f60718", uc.query.Filter.CategoryID)
+	return uc.query.Filter
+}
+
+func TestProductHandler_GetByCategory_IncludesDescendantsByDefault(t *testing.T) {
+	filter := getByCategory(t, "/categories/64b7f0c2a1b2c3d4e5f60718/products")
+
+	assert.Nil(t, filter.IncludeDescendants)
+}
+
+func TestProductHandler_GetByCategory_ExcludesDescendants(t *testing.T) {
+	filter := getByCategory(t, "/categories/64b7f0c2a1b2c3d4e5f60718/products?include_descendants=false")
+
+	require.NotNil(t, filter.IncludeDescendants)
+	assert.False(t, *filter.IncludeDescendants)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProductImageHandler struct {
+	BaseHandler
+	productImageUseCase domain.ProductImageUseCase
+	maxImageBytes       int64
+}
+
+func NewProductImageHandler(uc domain.ProductImageUseCase, maxImageBytes int64) ProductImageHandler {
+	return ProductImageHandler{
+		BaseHandler:         BaseHandler{validator: validator.NewValidator()},
+		productImageUseCase: uc,
+		maxImageBytes:       maxImageBytes,
+	}
+}
+
+func (h *ProductImageHandler) Upload(c echo.Context) error {
+	file, err := c.FormFile("image")
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.ProductImageMissingError)
+	}
+	if file.Size > h.maxImageBytes {
+		return response.ErrorResponse(c, http.StatusRequestEntityTooLarge, domain.ErrImageTooLarge.Error())
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, h.maxImageBytes+1))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+
+	image, err := h.productImageUseCase.Upload(c.Request().Context(), c.Param("id"), data)
+	if err != nil {
+		return imageErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.ProductImageUploadedSuccess, image)
+}
+
+func (h *ProductImageHandler) Reorder(c echo.Context) error {
+	var req struct {
+		ImageIDs []string `json:"image_ids" validate:"required"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	images, err := h.productImageUseCase.Reorder(c.Request().Context(), c.Param("id"), req.ImageIDs)
+	if err != nil {
+		return imageErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductImagesReorderedSuccess, images)
+}
+
+func (h *ProductImageHandler) Delete(c echo.Context) error {
+	if err := h.productImageUseCase.Delete(c.Request().Context(), c.Param("id"), c.Param("image_id")); err != nil {
+		return imageErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ProductImageDeletedSuccess, nil)
+}
+
+func imageErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrImageTooLarge, domain.ErrImageTooManyPixels:
+		return response.ErrorResponse(c, http.StatusRequestEntityTooLarge, err.Error())
+	case domain.ErrUnsupportedImageType:
+		return response.ErrorResponse(c, http.StatusUnsupportedMediaType, err.Error())
+	case domain.ErrInvalidProductID, domain.ErrInvalidProductData:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrProductNotFound, domain.ErrImageNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type RecommendationHandler struct {
+	BaseHandler
+	recommendationUseCase domain.RecommendationUseCase
+}
+
+func NewRecommendationHandler(uc domain.RecommendationUseCase) RecommendationHandler {
+	return RecommendationHandler{
+		BaseHandler:           BaseHandler{validator: validator.NewValidator()},
+		recommendationUseCase: uc,
+	}
+}
+
+func (h *RecommendationHandler) ForProduct(c echo.Context) error {
+	products, err := h.recommendationUseCase.ForProduct(c.Request().Context(), c.Param("id"), parseInt(c.QueryParam("limit")))
+	if err != nil {
+		return recommendationErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.RecommendationsRetrievedSuccess, products)
+}
+
+func (h *RecommendationHandler) ForCart(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	products, err := h.recommendationUseCase.ForCart(c.Request().Context(), claims.UserID, claims.Role == "admin", c.Param("id"), parseInt(c.QueryParam("limit")))
+	if err != nil {
+		return recommendationErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.RecommendationsRetrievedSuccess, products)
+}
+
+func recommendationErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidProductID, domain.ErrInvalidCartID:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrCartNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewHandler struct {
+	BaseHandler
+	reviewUseCase domain.ReviewUseCase
+}
+
+func NewReviewHandler(uc domain.ReviewUseCase) ReviewHandler {
+	return ReviewHandler{
+		BaseHandler:   BaseHandler{validator: validator.NewValidator()},
+		reviewUseCase: uc,
+	}
+}
+
+func (h *ReviewHandler) Create(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var review domain.Review
+	if err := c.Bind(&review); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&review); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	productID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidProductID.Error())
+	}
+	review.ProductID = productID
+
+	if err := h.reviewUseCase.Create(c.Request().Context(), claims.UserID, &review); err != nil {
+		return reviewErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.ReviewCreatedSuccess, review)
+}
+
+func (h *ReviewHandler) GetByProductID(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	reviews, pagination, err := h.reviewUseCase.GetByProductID(c.Request().Context(), c.Param("id"), query)
+	if err != nil {
+		if err == domain.ErrInvalidProductID {
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.ReviewsRetrievedSuccess, reviews, pagination)
+}
+
+func (h *ReviewHandler) GetAll(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	reviews, pagination, err := h.reviewUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		if err == domain.ErrInvalidReviewStatus {
+			return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		}
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.ReviewsRetrievedSuccess, reviews, pagination)
+}
+
+func (h *ReviewHandler) Update(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var review domain.Review
+	if err := c.Bind(&review); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&review); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	productID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidProductID.Error())
+	}
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("review_id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidReviewID.Error())
+	}
+	review.ID = reviewID
+	review.ProductID = productID
+
+	if err := h.reviewUseCase.Update(c.Request().Context(), claims.UserID, &review); err != nil {
+		return reviewErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ReviewUpdatedSuccess, review)
+}
+
+func (h *ReviewHandler) Moderate(c echo.Context) error {
+	var moderation domain.ReviewModeration
+	if err := c.Bind(&moderation); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&moderation); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	review, err := h.reviewUseCase.Moderate(c.Request().Context(), c.Param("id"), moderation)
+	if err != nil {
+		return reviewErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ReviewModeratedSuccess, review)
+}
+
+func (h *ReviewHandler) Delete(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	id := c.Param("review_id")
+	if id == "" {
+		id = c.Param("id")
+	}
+	if err := h.reviewUseCase.Delete(c.Request().Context(), claims.UserID, claims.Role == "admin", id); err != nil {
+		return reviewErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.ReviewDeletedSuccess, nil)
+}
+
+func reviewErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidProductID, domain.ErrInvalidReviewID, domain.ErrInvalidUserID, domain.ErrInvalidReviewStatus:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrReviewNotPurchased, domain.ErrReviewForbidden:
+		return response.ErrorResponse(c, http.StatusForbidden, err.Error())
+	case domain.ErrProductNotFound, domain.ErrReviewNotFound, domain.ErrUserNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case domain.ErrReviewAlreadyExists:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SegmentHandler struct {
+	BaseHandler
+	segmentUseCase domain.SegmentUseCase
+}
+
+func NewSegmentHandler(uc domain.SegmentUseCase) SegmentHandler {
+	return SegmentHandler{
+		BaseHandler:    BaseHandler{validator: validator.NewValidator()},
+		segmentUseCase: uc,
+	}
+}
+
+func (h *SegmentHandler) Create(c echo.Context) error {
+	var segment domain.Segment
+	if err := c.Bind(&segment); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&segment); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err := h.segmentUseCase.Create(c.Request().Context(), &segment); err != nil {
+		return segmentErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.SegmentCreatedSuccess, segment)
+}
+
+func (h *SegmentHandler) GetByID(c echo.Context) error {
+	segment, err := h.segmentUseCase.GetByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return segmentErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.SegmentRetrievedSuccess, segment)
+}
+
+func (h *SegmentHandler) GetAll(c echo.Context) error {
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	segments, pagination, err := h.segmentUseCase.GetAll(c.Request().Context(), query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.SegmentsRetrievedSuccess, segments, pagination)
+}
+
+func (h *SegmentHandler) Update(c echo.Context) error {
+	objectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidSegmentID.Error())
+	}
+
+	var segment domain.Segment
+	if err := c.Bind(&segment); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&segment); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	segment.ID = objectID
+	if err := h.segmentUseCase.Update(c.Request().Context(), &segment); err != nil {
+		return segmentErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.SegmentUpdatedSuccess, segment)
+}
+
+func (h *SegmentHandler) Delete(c echo.Context) error {
+	if err := h.segmentUseCase.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return segmentErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.SegmentDeletedSuccess, nil)
+}
+
+func segmentErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidSegmentID, domain.ErrInvalidSegmentRule:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrSegmentNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case domain.ErrSegmentExists, domain.ErrSegmentInUse:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
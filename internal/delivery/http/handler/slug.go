@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+	"path"
+
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// redirectToSlug sends clients that used a retired slug to the canonical
+// URL, keeping the query string intact.
+func redirectToSlug(c echo.Context, canonical string) error {
+	target := path.Join(path.Dir(c.Request().URL.Path), canonical)
+	if query := c.QueryString(); query != "" {
+		target += "?" + query
+	}
+	return c.Redirect(http.StatusMovedPermanently, target)
+}
+
+func slugErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidSlug:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrSlugTaken:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
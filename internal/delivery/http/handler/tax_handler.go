@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TaxHandler struct {
+	BaseHandler
+	taxUseCase domain.TaxUseCase
+}
+
+func NewTaxHandler(uc domain.TaxUseCase) TaxHandler {
+	return TaxHandler{
+		BaseHandler: BaseHandler{validator: validator.NewValidator()},
+		taxUseCase:  uc,
+	}
+}
+
+func (h *TaxHandler) GetRegions(c echo.Context) error {
+	regions, err := h.taxUseCase.GetRegions(c.Request().Context())
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.TaxRegionsRetrievedSuccess, regions)
+}
+
+// SetRegion creates or replaces the region in the path. Rates are
+// percentages per tax class.
+func (h *TaxHandler) SetRegion(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var region domain.TaxRegion
+	if err := c.Bind(&region); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&region); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	region.Code = c.Param("code")
+	region.UpdatedBy = claims.Email
+	if err := h.taxUseCase.SetRegion(c.Request().Context(), &region); err != nil {
+		return taxErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.TaxRegionSetSuccess, region)
+}
+
+func (h *TaxHandler) DeleteRegion(c echo.Context) error {
+	if err := h.taxUseCase.DeleteRegion(c.Request().Context(), c.Param("code")); err != nil {
+		return taxErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.TaxRegionDeletedSuccess, nil)
+}
+
+func taxErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidTaxRegion:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrTaxRegionNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
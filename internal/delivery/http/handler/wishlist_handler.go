@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+
+	"play-to-win-api/internal/constants"
+	"play-to-win-api/internal/delivery/http/middleware"
+	"play-to-win-api/internal/delivery/http/response"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WishlistHandler struct {
+	BaseHandler
+	wishlistUseCase domain.WishlistUseCase
+}
+
+func NewWishlistHandler(uc domain.WishlistUseCase) WishlistHandler {
+	return WishlistHandler{
+		BaseHandler:     BaseHandler{validator: validator.NewValidator()},
+		wishlistUseCase: uc,
+	}
+}
+
+func (h *WishlistHandler) Add(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var item domain.WishlistItem
+	if err := c.Bind(&item); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+
+	if err := h.wishlistUseCase.Add(c.Request().Context(), claims.UserID, &item); err != nil {
+		return wishlistErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.WishlistItemAddedSuccess, item)
+}
+
+func (h *WishlistHandler) GetAll(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	query, err := parseListQuery(c)
+	if err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	items, pagination, err := h.wishlistUseCase.GetByUserID(c.Request().Context(), claims.UserID, query)
+	if err != nil {
+		return listErrorResponse(c, err)
+	}
+
+	return response.NewPaginatedResponse(c, http.StatusOK, constants.WishlistRetrievedSuccess, items, pagination)
+}
+
+func (h *WishlistHandler) UpdatePreferences(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var prefs domain.WishlistPreferences
+	if err := c.Bind(&prefs); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+
+	if err := h.wishlistUseCase.UpdatePreferences(c.Request().Context(), claims.UserID, c.Param("id"), prefs); err != nil {
+		return wishlistErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.WishlistItemUpdatedSuccess, prefs)
+}
+
+func (h *WishlistHandler) Remove(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	if err := h.wishlistUseCase.Remove(c.Request().Context(), claims.UserID, c.Param("id")); err != nil {
+		return wishlistErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusOK, constants.WishlistItemRemovedSuccess, nil)
+}
+
+func (h *WishlistHandler) MoveToCart(c echo.Context) error {
+	claims, ok := c.Get("user").(*middleware.Claims)
+	if !ok {
+		return response.ErrorResponse(c, http.StatusInternalServerError, constants.InternalServerError)
+	}
+
+	var move domain.WishlistMove
+	if err := c.Bind(&move); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, constants.InvalidRequestError)
+	}
+	if err := h.validator.Validate(&move); err != nil {
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	cartItem, err := h.wishlistUseCase.MoveToCart(c.Request().Context(), claims.UserID, c.Param("id"), move)
+	if err != nil {
+		return wishlistErrorResponse(c, err)
+	}
+
+	return response.NewResponse(c, http.StatusCreated, constants.WishlistItemMovedSuccess, cartItem)
+}
+
+func wishlistErrorResponse(c echo.Context, err error) error {
+	switch err {
+	case domain.ErrInvalidProductID, domain.ErrInvalidWishlistID, domain.ErrInvalidUserID,
+		domain.ErrVariantRequired, domain.ErrVariantNotFound, domain.ErrInsufficientStock:
+		return response.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	case domain.ErrProductNotFound, domain.ErrWishlistItemNotFound, domain.ErrCartNotFound:
+		return response.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case domain.ErrWishlistItemExists:
+		return response.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		return response.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
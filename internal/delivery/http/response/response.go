@@ -5,10 +5,11 @@ import (
 )
 
 type Response struct {
-	Status  bool        `json:"status"`
-	Message string      `json:"message"`
-	Code    int         `json:"code"`
-	Data    interface{} `json:"data"`
+	Status     bool        `json:"status"`
+	Message    string      `json:"message"`
+	Code       int         `json:"code"`
+	Data       interface{} `json:"data"`
+	Pagination interface{} `json:"pagination,omitempty"`
 }
 
 func NewResponse(c echo.Context, code int, message string, data interface{}) error {
@@ -20,6 +21,16 @@ func NewResponse(c echo.Context, code int, message string, data interface{}) err
 	})
 }
 
+func NewPaginatedResponse(c echo.Context, code int, message string, data interface{}, pagination interface{}) error {
+	return c.JSON(code, Response{
+		Status:     code >= 200 && code < 300,
+		Message:    message,
+		Code:       code,
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
 func ErrorResponse(c echo.Context, code int, message string) error {
 	return NewResponse(c, code, message, nil)
 }
@@ -14,7 +14,12 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 
 	categories := v1.Group("/categories")
 	categories.GET("", handlers.Category.GetAll)
+	categories.GET("/tree", handlers.Category.GetTree)
+	categories.GET("/slug/:slug", handlers.Category.GetBySlug)
 	categories.GET("/:id", handlers.Category.GetByID)
+	categories.GET("/:id/tree", handlers.Category.GetSubtree)
+	categories.GET("/:id/breadcrumbs", handlers.Category.GetBreadcrumbs)
+	categories.GET("/:id/products", handlers.Product.GetByCategory)
 
 	protectedCategories := categories.Group("")
 	protectedCategories.Use(handlers.AuthMW.Authenticate)
@@ -24,6 +29,9 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 	adminCategories.POST("", handlers.Category.Create)
 	adminCategories.PUT("/:id", handlers.Category.Update)
 	adminCategories.DELETE("/:id", handlers.Category.Delete)
+	adminCategories.GET("/archived", handlers.Category.GetArchived)
+	adminCategories.POST("/:id/restore", handlers.Category.Restore)
+	adminCategories.GET("/export", handlers.CatalogTransfer.ExportCategories)
 
 	auth := v1.Group("/auth")
 	auth.POST("/register", handlers.Auth.Register)
@@ -36,16 +44,49 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 
 	products := v1.Group("/products")
 	products.GET("", handlers.Product.GetAll)
+	products.GET("/search", handlers.Product.Search)
+	products.GET("/suggest", handlers.Product.Suggest)
+	products.GET("/slug/:slug", handlers.Product.GetBySlug)
 	products.GET("/:id", handlers.Product.GetByID)
+	products.GET("/:id/reviews", handlers.Review.GetByProductID)
+	products.GET("/:id/recommendations", handlers.Recommendation.ForProduct)
 
 	protectedProducts := products.Group("")
 	protectedProducts.Use(handlers.AuthMW.Authenticate)
+	protectedProducts.POST("/:id/reviews", handlers.Review.Create)
+	protectedProducts.PUT("/:id/reviews/:review_id", handlers.Review.Update)
+	protectedProducts.DELETE("/:id/reviews/:review_id", handlers.Review.Delete)
 
 	adminProducts := protectedProducts.Group("")
 	adminProducts.Use(middleware.RequireRole("admin"))
 	adminProducts.POST("", handlers.Product.Create)
+	adminProducts.POST("/import", handlers.CatalogTransfer.ImportProducts)
+	adminProducts.GET("/export", handlers.CatalogTransfer.ExportProducts)
 	adminProducts.PUT("/:id", handlers.Product.Update)
 	adminProducts.DELETE("/:id", handlers.Product.Delete)
+	adminProducts.GET("/archived", handlers.Product.GetArchived)
+	adminProducts.POST("/:id/restore", handlers.Product.Restore)
+	adminProducts.POST("/:id/variants", handlers.Product.AddVariant)
+	adminProducts.PUT("/:id/variants/:variant_id", handlers.Product.UpdateVariant)
+	adminProducts.DELETE("/:id/variants/:variant_id", handlers.Product.DeleteVariant)
+	adminProducts.GET("/:id/prices", handlers.Pricing.GetHistory)
+	adminProducts.POST("/:id/prices", handlers.Pricing.Schedule)
+	adminProducts.DELETE("/:id/prices/:change_id", handlers.Pricing.Cancel)
+	adminProducts.POST("/:id/stock-adjustments", handlers.Inventory.AdjustStock)
+	adminProducts.GET("/:id/stock-movements", handlers.Inventory.GetMovements)
+	adminProducts.POST("/:id/images", handlers.ProductImage.Upload)
+	adminProducts.PUT("/:id/images/order", handlers.ProductImage.Reorder)
+	adminProducts.DELETE("/:id/images/:image_id", handlers.ProductImage.Delete)
+
+	inventory := v1.Group("/inventory")
+	inventory.Use(handlers.AuthMW.Authenticate, middleware.RequireRole("admin"))
+	inventory.GET("/low-stock", handlers.Inventory.GetLowStock)
+
+	reviews := v1.Group("/reviews")
+	reviews.Use(handlers.AuthMW.Authenticate, middleware.RequireRole("admin"))
+	reviews.GET("", handlers.Review.GetAll)
+	reviews.PUT("/:id/moderation", handlers.Review.Moderate)
+	reviews.DELETE("/:id", handlers.Review.Delete)
 
 	campaigns := v1.Group("/campaigns")
 	campaigns.GET("", handlers.Campaign.GetAll)
@@ -59,6 +100,11 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 	adminCampaigns.POST("", handlers.Campaign.Create)
 	adminCampaigns.PUT("/:id", handlers.Campaign.Update)
 	adminCampaigns.DELETE("/:id", handlers.Campaign.Delete)
+	adminCampaigns.GET("/archived", handlers.Campaign.GetArchived)
+	adminCampaigns.GET("/conflicts", handlers.Campaign.GetConflicts)
+	adminCampaigns.POST("/simulate", handlers.Campaign.Simulate)
+	adminCampaigns.GET("/:id/stats", handlers.Campaign.GetStats)
+	adminCampaigns.POST("/:id/restore", handlers.Campaign.Restore)
 
 	carts := v1.Group("/carts")
 
@@ -67,10 +113,16 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 
 	protectedCart.GET("", handlers.Cart.GetByUserID)
 	protectedCart.GET("/:id", handlers.Cart.GetByID)
+	protectedCart.GET("/:id/recommendations", handlers.Recommendation.ForCart)
+	protectedCart.POST("/:id/checkout", handlers.Order.Checkout)
 	protectedCart.POST("", handlers.Cart.Create)
 	protectedCart.PUT("/:id", handlers.Cart.Update)
 	protectedCart.DELETE("/:id", handlers.Cart.Delete)
 
+	adminCart := protectedCart.Group("")
+	adminCart.Use(middleware.RequireRole("admin"))
+	adminCart.GET("/all", handlers.Cart.GetAll)
+
 	cartItems := v1.Group("/cart-items")
 
 	protectedCartItems := cartItems.Group("")
@@ -85,6 +137,24 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 	adminCartItems.Use(middleware.RequireRole("admin"))
 	adminCartItems.GET("", handlers.CartItem.GetAll)
 
+	wishlist := v1.Group("/wishlist")
+	wishlist.Use(handlers.AuthMW.Authenticate)
+	wishlist.GET("", handlers.Wishlist.GetAll)
+	wishlist.POST("", handlers.Wishlist.Add)
+	wishlist.PUT("/:id", handlers.Wishlist.UpdatePreferences)
+	wishlist.DELETE("/:id", handlers.Wishlist.Remove)
+	wishlist.POST("/:id/move-to-cart", handlers.Wishlist.MoveToCart)
+
+	orders := v1.Group("/orders")
+	orders.Use(handlers.AuthMW.Authenticate)
+	orders.GET("", handlers.Order.GetAll)
+	orders.GET("/:id", handlers.Order.GetByID)
+
+	notifications := v1.Group("/notifications")
+	notifications.Use(handlers.AuthMW.Authenticate)
+	notifications.GET("", handlers.Notification.GetAll)
+	notifications.PUT("/:id/read", handlers.Notification.MarkRead)
+
 	discountRule := v1.Group("/discount-rules")
 	discountRule.GET("", handlers.DiscountRule.GetAll)
 	discountRule.GET("/:id", handlers.DiscountRule.GetByID)
@@ -103,6 +173,37 @@ func SetupRoutes(e *echo.Echo, handlers *handler.Handlers) {
 	discounts.GET("/fixed-amount/:cart_id", handlers.Discount.CalculateFixedAmount)
 	discounts.GET("/percentage/:cart_id", handlers.Discount.CalculatePercentage)
 	discounts.GET("/category/:cart_id", handlers.Discount.CalculateCategoryDiscount)
+	discounts.GET("/variant/:cart_id", handlers.Discount.CalculateVariantDiscount)
 	discounts.GET("/points/:cart_id", handlers.Discount.CalculatePointsDiscount)
 	discounts.GET("/special/:cart_id", handlers.Discount.CalculateSpecialDiscount)
+	discounts.GET("/buy-x-get-y/:cart_id", handlers.Discount.CalculateBuyXGetYDiscount)
+	discounts.GET("/bundle/:cart_id", handlers.Discount.CalculateBundleDiscount)
+
+	protectedDiscounts := discounts.Group("")
+	protectedDiscounts.Use(handlers.AuthMW.Authenticate)
+	protectedDiscounts.GET("/campaigns/:cart_id", handlers.Discount.CalculateCampaignDiscount)
+
+	segments := v1.Group("/segments")
+	segments.Use(handlers.AuthMW.Authenticate, middleware.RequireRole("admin"))
+	segments.GET("", handlers.Segment.GetAll)
+	segments.GET("/:id", handlers.Segment.GetByID)
+	segments.POST("", handlers.Segment.Create)
+	segments.PUT("/:id", handlers.Segment.Update)
+	segments.DELETE("/:id", handlers.Segment.Delete)
+
+	exchangeRates := v1.Group("/exchange-rates")
+	exchangeRates.GET("", handlers.ExchangeRate.GetAll)
+
+	adminExchangeRates := exchangeRates.Group("")
+	adminExchangeRates.Use(handlers.AuthMW.Authenticate, middleware.RequireRole("admin"))
+	adminExchangeRates.PUT("/:currency", handlers.ExchangeRate.Set)
+	adminExchangeRates.DELETE("/:currency", handlers.ExchangeRate.Delete)
+
+	taxRegions := v1.Group("/tax-regions")
+	taxRegions.GET("", handlers.Tax.GetRegions)
+
+	adminTaxRegions := taxRegions.Group("")
+	adminTaxRegions.Use(handlers.AuthMW.Authenticate, middleware.RequireRole("admin"))
+	adminTaxRegions.PUT("/:code", handlers.Tax.SetRegion)
+	adminTaxRegions.DELETE("/:code", handlers.Tax.DeleteRegion)
 }
@@ -5,17 +5,23 @@ import (
 )
 
 type AppliedDiscount struct {
-	Category   string  `json:"category"`
-	Quantity   int     `json:"quantity"`
-	UnitPrice  float64 `json:"unit_price"`
-	TotalPrice float64 `json:"total_price"`
-	Points     int     `json:"points"`
+	Category   string `json:"category"`
+	Quantity   int    `json:"quantity"`
+	UnitPrice  Money  `json:"unit_price"`
+	TotalPrice Money  `json:"total_price"`
+	Points     int    `json:"points"`
 }
 
+// AppliedDiscountUseCase works out each kind of discount on a cart and
+// returns it allocated to the cart lines it comes off.
 type AppliedDiscountUseCase interface {
-	CalculateFixedAmountDiscount(ctx context.Context, cartItems []CartItem, amount float64) (float64, error)
-	CalculatePercentageDiscount(ctx context.Context, cartItems []CartItem, percentage float64) (float64, error)
-	CalculateCategoryDiscount(ctx context.Context, cartItems []CartItem, category string, percentage float64) (float64, error)
-	CalculatePointsDiscount(ctx context.Context, cartItems []CartItem, points int) (float64, error)
-	CalculateSpecialDiscount(ctx context.Context, cartItems []CartItem, threshold, discount float64) (float64, error)
+	CalculateFixedAmountDiscount(ctx context.Context, cartItems []CartItem, amount Money) ([]DiscountAllocation, error)
+	CalculatePercentageDiscount(ctx context.Context, cartItems []CartItem, percentage float64) ([]DiscountAllocation, error)
+	CalculateCategoryDiscount(ctx context.Context, cartItems []CartItem, category string, percentage float64) ([]DiscountAllocation, error)
+	CalculateVariantDiscount(ctx context.Context, cartItems []CartItem, sku string, percentage float64) ([]DiscountAllocation, error)
+	CalculatePointsDiscount(ctx context.Context, cartItems []CartItem, points int) ([]DiscountAllocation, error)
+	CalculateSpecialDiscount(ctx context.Context, cartItems []CartItem, threshold, discount Money) ([]DiscountAllocation, error)
+	CalculateBuyXGetYDiscount(ctx context.Context, cartItems []CartItem, offer BuyXGetY) ([]DiscountAllocation, error)
+	CalculateBundleDiscount(ctx context.Context, cartItems []CartItem, bundle Bundle) ([]DiscountAllocation, error)
+	CalculateTieredDiscount(ctx context.Context, cartItems []CartItem, pricing TieredPricing) ([]DiscountAllocation, error)
 }
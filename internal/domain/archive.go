@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ArchivePurger permanently removes soft-deleted documents once they have
+// been archived for longer than the retention period.
+type ArchivePurger interface {
+	PurgeArchived(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CampaignConflict describes two campaigns whose windows overlap while they
+// target the same category or stack the same kind of rule. Blocking conflicts
+// are rejected; the rest are reported as warnings.
+type CampaignConflict struct {
+	CampaignID      primitive.ObjectID `json:"campaign_id"`
+	CampaignName    string             `json:"campaign_name"`
+	ConflictingID   primitive.ObjectID `json:"conflicting_campaign_id"`
+	ConflictingName string             `json:"conflicting_campaign_name"`
+	OverlapStart    time.Time          `json:"overlap_start"`
+	OverlapEnd      time.Time          `json:"overlap_end"`
+	Reasons         []string           `json:"reasons"`
+	Blocking        bool               `json:"blocking"`
+}
+
+// Target identifies what a rule discounts, so rules of the same type aimed at
+// different categories or SKUs do not count as stacking.
+func (r *DiscountRule) Target() string {
+	switch r.DiscuntType {
+	case DiscountTypeCategory:
+		return r.DiscuntType + ":" + strings.ToLower(r.ItemCategory)
+	case DiscountTypeVariant:
+		return r.DiscuntType + ":" + r.ItemSKU
+	case DiscountTypeBOGO, DiscountTypeBuyXGetY:
+		return r.DiscuntType + ":" + r.BuyXGetY().Buy.key()
+	case DiscountTypeTiered:
+		return r.DiscuntType + ":" + strings.ToLower(r.ItemCategory)
+	case DiscountTypeBundle:
+		return r.DiscuntType + ":" + joinObjectIDs(r.BundleProductIDs)
+	default:
+		return r.DiscuntType
+	}
+}
+
+// Overlaps reports whether the two campaigns' windows intersect.
+func (c *Campaign) Overlaps(other *Campaign) bool {
+	return c.StartDate.Before(other.EndDate) && other.StartDate.Before(c.EndDate)
+}
+
+// DetectCampaignConflict compares two campaigns and their rules, returning
+// nil when they can safely run side by side. An exclusive campaign that
+// overlaps another of equal priority is blocking, since evaluation would have
+// no way to decide which one wins.
+func DetectCampaignConflict(a Campaign, aRules []DiscountRule, b Campaign, bRules []DiscountRule) *CampaignConflict {
+	if a.ID == b.ID || !a.Overlaps(&b) {
+		return nil
+	}
+
+	var reasons []string
+	if a.Category != "" && strings.EqualFold(a.Category, b.Category) {
+		reasons = append(reasons, "category:"+a.Category)
+	}
+	targets := map[string]bool{}
+	for i := range aRules {
+		targets[aRules[i].Target()] = true
+	}
+	shared := map[string]bool{}
+	for i := range bRules {
+		if target := bRules[i].Target(); targets[target] && !shared[target] {
+			shared[target] = true
+			reasons = append(reasons, "rule:"+target)
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	sort.Strings(reasons)
+
+	conflict := &CampaignConflict{
+		CampaignID:      a.ID,
+		CampaignName:    a.Name,
+		ConflictingID:   b.ID,
+		ConflictingName: b.Name,
+		OverlapStart:    a.StartDate,
+		OverlapEnd:      a.EndDate,
+		Reasons:         reasons,
+		Blocking:        (a.Exclusive || b.Exclusive) && a.Priority == b.Priority,
+	}
+	if b.StartDate.After(conflict.OverlapStart) {
+		conflict.OverlapStart = b.StartDate
+	}
+	if b.EndDate.Before(conflict.OverlapEnd) {
+		conflict.OverlapEnd = b.EndDate
+	}
+	return conflict
+}
+
+// BlockingConflicts filters conflicts down to those that must be rejected.
+func BlockingConflicts(conflicts []CampaignConflict) []CampaignConflict {
+	blocking := []CampaignConflict{}
+	for _, conflict := range conflicts {
+		if conflict.Blocking {
+			blocking = append(blocking, conflict)
+		}
+	}
+	return blocking
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testCampaign(category string, start time.Time, days int) Campaign {
+	return Campaign{ID: primitive.NewObjectID(), Name: category, Category: category, StartDate: start, EndDate: start.AddDate(0, 0, days)}
+}
+
+func TestDetectCampaignConflict_SameRuleTargetWarns(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := testCampaign("Summer", start, 10)
+	b := testCampaign("Flash", start.AddDate(0, 0, 5), 10)
+	rule := DiscountRule{DiscuntType: DiscountTypeCategory, ItemCategory: "Shoes"}
+
+	conflict := DetectCampaignConflict(a, []DiscountRule{rule}, b, []DiscountRule{rule})
+
+	assert.NotNil(t, conflict)
+	assert.Equal(t, []string{"rule:category:shoes"}, conflict.Reasons)
+	assert.Equal(t, b.StartDate, conflict.OverlapStart)
+	assert.Equal(t, a.EndDate, conflict.OverlapEnd)
+	assert.False(t, conflict.Blocking)
+}
+
+func TestDetectCampaignConflict_ExclusiveEqualPriorityBlocks(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := testCampaign("Shoes", start, 10)
+	a.Exclusive = true
+	b := testCampaign("Shoes", start, 3)
+
+	assert.True(t, DetectCampaignConflict(a, nil, b, nil).Blocking)
+
+	b.Priority = 1
+	assert.False(t, DetectCampaignConflict(a, nil, b, nil).Blocking)
+}
+
+func TestDetectCampaignConflict_DisjointWindowsDoNotConflict(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := testCampaign("Shoes", start, 10)
+	b := testCampaign("Shoes", a.EndDate, 10)
+
+	assert.Nil(t, DetectCampaignConflict(a, nil, b, nil))
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultSimulationWindow is how far back a simulation replays when no range
+// is given.
+const DefaultSimulationWindow = 30 * 24 * time.Hour
+
+// CampaignSimulationRequest is a draft campaign and its rules, replayed
+// against the orders placed within [From, To]. Nothing in it is persisted.
+type CampaignSimulationRequest struct {
+	Campaign Campaign       `json:"campaign"`
+	Rules    []DiscountRule `json:"rules"`
+	From     *time.Time     `json:"from"`
+	To       *time.Time     `json:"to"`
+}
+
+// CampaignSimulation is what the draft campaign would have cost. Orders are
+// replayed oldest first against their undiscounted subtotal, with the
+// campaign's caps applied as they would have been at checkout. We do not
+// record cost prices, so the margin impact is the share of gross revenue the
+// campaign gives away, in percentage points.
+type CampaignSimulation struct {
+	From              time.Time  `json:"from"`
+	To                time.Time  `json:"to"`
+	Orders            int64      `json:"orders"`
+	AffectedOrders    int64      `json:"affected_orders"`
+	Customers         int64      `json:"customers"`
+	GrossRevenue      Money      `json:"gross_revenue"`
+	ProjectedDiscount Money      `json:"projected_discount"`
+	ProjectedRevenue  Money      `json:"projected_revenue"`
+	AverageDiscount   Money      `json:"average_discount"`
+	MarginImpact      float64    `json:"margin_impact"`
+	ExhaustedAt       *time.Time `json:"exhausted_at,omitempty"`
+}
+
+type CampaignSimulationUseCase interface {
+	Simulate(ctx context.Context, request CampaignSimulationRequest) (*CampaignSimulation, error)
+}
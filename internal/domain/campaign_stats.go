@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const DefaultCampaignTopProducts = 10
+
+// CampaignStatsQuery limits stats to orders placed within [From, To].
+type CampaignStatsQuery struct {
+	From        *time.Time
+	To          *time.Time
+	TopProducts int
+}
+
+type CampaignProductStats struct {
+	ProductID   primitive.ObjectID `bson:"_id" json:"product_id"`
+	ProductName string             `bson:"product_name" json:"product_name"`
+	Quantity    int                `bson:"quantity" json:"quantity"`
+	Revenue     Money              `bson:"revenue" json:"revenue"`
+}
+
+// CampaignStats summarises the orders that redeemed a campaign. Gross revenue
+// is before any discount and net revenue is what customers paid. The average
+// order value without the campaign covers the other orders in the same range,
+// as a baseline. Only orders in the base currency are counted.
+type CampaignStats struct {
+	CampaignID               primitive.ObjectID     `json:"campaign_id"`
+	CampaignName             string                 `json:"campaign_name"`
+	From                     *time.Time             `json:"from,omitempty"`
+	To                       *time.Time             `json:"to,omitempty"`
+	Redemptions              int64                  `json:"redemptions"`
+	Customers                int64                  `json:"customers"`
+	DiscountGiven            Money                  `json:"discount_given"`
+	GrossRevenue             Money                  `json:"gross_revenue"`
+	NetRevenue               Money                  `json:"net_revenue"`
+	AverageOrderValue        Money                  `json:"average_order_value"`
+	OrdersWithout            int64                  `json:"orders_without"`
+	AverageOrderValueWithout Money                  `json:"average_order_value_without"`
+	TopProducts              []CampaignProductStats `json:"top_products"`
+}
@@ -11,25 +11,116 @@ type Campaign struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
 	Name      string             `bson:"name" json:"name" validate:"required"`
 	Category  string             `bson:"category" json:"category" validate:"required"`
-	IsActive  bool               `bson:"is_active" json:"is_active" default:"true"`
-	StartDate time.Time          `bson:"start_date" json:"start_date" default:"time.Now()"`
-	EndDate   time.Time          `bson:"end_date" json:"end_date" default:"time.Now().AddDate(0, 0, 7)"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	IsActive  bool               `bson:"is_active" json:"is_active"`
+	StartDate time.Time          `bson:"start_date" json:"start_date"`
+	EndDate   time.Time          `bson:"end_date" json:"end_date"`
+	Exclusive bool               `bson:"exclusive" json:"exclusive"`
+	Priority  int                `bson:"priority" json:"priority"`
+
+	// SegmentIDs restricts the campaign to customers in any of the segments.
+	// An empty list targets everyone.
+	SegmentIDs []primitive.ObjectID `bson:"segment_ids,omitempty" json:"segment_ids,omitempty"`
+
+	// Caps are unlimited when zero. Spent and Redemptions are maintained by
+	// checkout with atomic updates and never written by Update.
+	Budget                    Money      `bson:"budget" json:"budget"`
+	MaxRedemptions            int        `bson:"max_redemptions" json:"max_redemptions"`
+	MaxRedemptionsPerCustomer int        `bson:"max_redemptions_per_customer" json:"max_redemptions_per_customer"`
+	Spent                     Money      `bson:"spent,omitempty" json:"spent"`
+	Redemptions               int        `bson:"redemptions,omitempty" json:"redemptions"`
+	ExhaustedAt               *time.Time `bson:"exhausted_at,omitempty" json:"exhausted_at,omitempty"`
+
+	StartedAt *time.Time `bson:"started_at,omitempty" json:"started_at,omitempty"`
+	EndedAt   *time.Time `bson:"ended_at,omitempty" json:"ended_at,omitempty"`
+	CreatedAt time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time  `bson:"updated_at" json:"updated_at"`
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+
+	Conflicts []CampaignConflict `bson:"-" json:"conflicts,omitempty"`
+}
+
+// DefaultCampaignLength is used when a campaign is created without an end date.
+const DefaultCampaignLength = 7 * 24 * time.Hour
+
+// InWindow reports whether at falls within [StartDate, EndDate).
+func (c *Campaign) InWindow(at time.Time) bool {
+	return !at.Before(c.StartDate) && at.Before(c.EndDate)
+}
+
+// Live reports whether the campaign's discounts apply at the given time.
+// Marketing can still pause a campaign inside its window via IsActive.
+func (c *Campaign) Live(at time.Time) bool {
+	return c.IsActive && c.DeletedAt == nil && c.InWindow(at)
+}
+
+// ValidLimits reports whether the caps are usable; zero means unlimited.
+func (c *Campaign) ValidLimits() bool {
+	return c.Budget >= 0 && c.MaxRedemptions >= 0 && c.MaxRedemptionsPerCustomer >= 0
+}
+
+// Redemption is the outcome of charging a discount against a campaign.
+// Granted is below the requested amount when the budget only partly covers
+// it, and zero when the campaign can no longer be redeemed.
+type Redemption struct {
+	Granted   Money
+	Exhausted bool
+}
+
+// CampaignTransition is the payload of campaign start and end events.
+type CampaignTransition struct {
+	CampaignID primitive.ObjectID `json:"campaign_id"`
+	Name       string             `json:"name"`
+	StartDate  time.Time          `json:"start_date"`
+	EndDate    time.Time          `json:"end_date"`
 }
 
 type CampaignRepository interface {
 	Create(ctx context.Context, campaign *Campaign) error
 	FindByID(ctx context.Context, id string) (*Campaign, error)
-	FindAll(ctx context.Context) ([]Campaign, error)
+	FindAll(ctx context.Context, query ListQuery) ([]Campaign, *Pagination, error)
 	Update(ctx context.Context, campaign *Campaign) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	FindArchived(ctx context.Context, query ListQuery) ([]Campaign, *Pagination, error)
+	// FindDueToStart returns inactive campaigns whose window contains at and
+	// that the scheduler has not started yet.
+	FindDueToStart(ctx context.Context, at time.Time) ([]Campaign, error)
+	// FindDueToEnd returns active campaigns whose window closed before at.
+	FindDueToEnd(ctx context.Context, at time.Time) ([]Campaign, error)
+	// MarkStarted and MarkEnded flip IsActive only if nobody else has, so a
+	// transition is reported exactly once.
+	MarkStarted(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error)
+	MarkEnded(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error)
+	// Redeem charges up to amount against the campaign's budget and counts a
+	// redemption in one atomic update, switching the campaign off once either
+	// cap is reached.
+	Redeem(ctx context.Context, id primitive.ObjectID, amount Money, at time.Time) (*Redemption, error)
+	// Unredeem reverses a redemption when the order it was for is abandoned.
+	Unredeem(ctx context.Context, id primitive.ObjectID, amount Money) error
+	TargetsSegment(ctx context.Context, segmentID primitive.ObjectID) (bool, error)
+	// FindOverlapping returns campaigns whose window intersects [from, to).
+	FindOverlapping(ctx context.Context, from, to time.Time) ([]Campaign, error)
+	ArchivePurger
 }
 
 type CampaignUseCase interface {
 	Create(ctx context.Context, campaign *Campaign) error
 	GetByID(ctx context.Context, id string) (*Campaign, error)
-	GetAll(ctx context.Context) ([]Campaign, error)
+	GetAll(ctx context.Context, query ListQuery) ([]Campaign, *Pagination, error)
 	Update(ctx context.Context, campaign *Campaign) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*Campaign, error)
+	GetArchived(ctx context.Context, query ListQuery) ([]Campaign, *Pagination, error)
+	ApplySchedule(ctx context.Context, at time.Time) (started, ended int, err error)
+	GetConflicts(ctx context.Context) ([]CampaignConflict, error)
+	GetStats(ctx context.Context, id string, query CampaignStatsQuery) (*CampaignStats, error)
+}
+
+// CampaignRedemptionRepository tracks how often each customer has redeemed a
+// campaign so per-customer caps hold under concurrent checkouts.
+type CampaignRedemptionRepository interface {
+	// Claim counts one redemption for the customer unless limit is reached.
+	// A limit of zero means unlimited.
+	Claim(ctx context.Context, campaignID, userID primitive.ObjectID, limit int) (bool, error)
+	Release(ctx context.Context, campaignID, userID primitive.ObjectID) error
 }
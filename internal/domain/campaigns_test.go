@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaign_LiveOnlyInsideWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	campaign := Campaign{IsActive: true, StartDate: start, EndDate: start.Add(24 * time.Hour)}
+
+	assert.False(t, campaign.Live(start.Add(-time.Second)))
+	assert.True(t, campaign.Live(start))
+	assert.True(t, campaign.Live(start.Add(23*time.Hour)))
+	assert.False(t, campaign.Live(start.Add(24*time.Hour)))
+}
+
+func TestCampaign_PausedCampaignIsNotLive(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	campaign := Campaign{IsActive: false, StartDate: start, EndDate: start.Add(24 * time.Hour)}
+
+	assert.True(t, campaign.InWindow(time.Now()))
+	assert.False(t, campaign.Live(time.Now()))
+}
@@ -10,16 +10,20 @@ import (
 type Cart struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty"`
 	User        User               `bson:"user" json:"user"`
-	TotalAmount float64            `bson:"total_amount" json:"total_amount" validate:"required"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	TotalAmount Money              `bson:"total_amount" json:"total_amount" validate:"required"`
+	// Currency is chosen when the cart is created and prices every line.
+	Currency Currency `bson:"currency,omitempty" json:"currency"`
+	// TaxRegion is also chosen at creation; empty is the default region.
+	TaxRegion string    `bson:"tax_region,omitempty" json:"tax_region,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
 }
 
 type CartRepository interface {
 	Create(cart *Cart) error
 	FindByUserID(ctx context.Context, userID string) ([]Cart, error)
 	FindByID(ctx context.Context, id string) (*Cart, error)
-	FindAll(ctx context.Context) ([]Cart, error)
+	FindAll(ctx context.Context, query ListQuery) ([]Cart, *Pagination, error)
 	Update(ctx context.Context, cart *Cart) error
 	Delete(ctx context.Context, id string) error
 }
@@ -28,7 +32,7 @@ type CartUseCase interface {
 	Create(ctx context.Context, cart *Cart) error
 	GetByUserID(ctx context.Context, userID string) ([]Cart, error)
 	GetByID(ctx context.Context, id string) (*Cart, error)
-	GetAll(ctx context.Context) ([]Cart, error)
+	GetAll(ctx context.Context, query ListQuery) ([]Cart, *Pagination, error)
 	Update(ctx context.Context, cart *Cart) error
 	Delete(ctx context.Context, id string) error
 }
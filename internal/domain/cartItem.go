@@ -8,27 +8,50 @@ import (
 )
 
 type CartItem struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty"`
-	CartId     primitive.ObjectID `bson:"cart_id,omitempty" json:"cart_id" validate:"required"`
-	ProductId  primitive.ObjectID `bson:"product_id,omitempty" json:"product_id" validate:"required"`
-	Quantity   int                `bson:"quantity,omitempty" json:"quantity" validate:"required"`
-	Category   string             `bson:"category,omitempty" json:"category" validate:"required"`
-	UnitPrice  float64            `bson:"unit_price,omitempty" json:"unit_price" validate:"required"`
-	TotalPrice float64            `bson:"total_price,omitempty" json:"total_price" validate:"required"`
-	CreatedAt  time.Time          `bson:"created_at,omitempty" json:"created_at"`
-	UpdatedAt  time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
-
-	ProductName        string  `bson:"product_name,omitempty" json:"product_name"`
-	ProductDescription string  `bson:"product_description,omitempty" json:"product_description"`
-	ProductImage       string  `bson:"product_image,omitempty" json:"product_image"`
-	ProductPrice       float64 `bson:"product_price,omitempty" json:"product_price"`
+	ID         primitive.ObjectID  `bson:"_id,omitempty"`
+	CartId     primitive.ObjectID  `bson:"cart_id,omitempty" json:"cart_id" validate:"required"`
+	ProductId  primitive.ObjectID  `bson:"product_id,omitempty" json:"product_id" validate:"required"`
+	VariantId  *primitive.ObjectID `bson:"variant_id,omitempty" json:"variant_id,omitempty"`
+	Quantity   int                 `bson:"quantity,omitempty" json:"quantity" validate:"required"`
+	CategoryID primitive.ObjectID  `bson:"category_id,omitempty" json:"category_id,omitempty"`
+	Category   string              `bson:"category,omitempty" json:"category"`
+	UnitPrice  Money               `bson:"unit_price,omitempty" json:"unit_price" validate:"required"`
+	TotalPrice Money               `bson:"total_price,omitempty" json:"total_price" validate:"required"`
+	Currency   Currency            `bson:"currency,omitempty" json:"currency"`
+	CreatedAt  time.Time           `bson:"created_at,omitempty" json:"created_at"`
+	UpdatedAt  time.Time           `bson:"updated_at,omitempty" json:"updated_at"`
+
+	ProductName        string   `bson:"product_name,omitempty" json:"product_name"`
+	ProductDescription string   `bson:"product_description,omitempty" json:"product_description"`
+	ProductImage       string   `bson:"product_image,omitempty" json:"product_image"`
+	ProductPrice       Money    `bson:"product_price,omitempty" json:"product_price"`
+	OnSale             bool     `bson:"on_sale,omitempty" json:"on_sale"`
+	TaxClass           TaxClass `bson:"tax_class,omitempty" json:"tax_class,omitempty"`
+
+	VariantSKU     string            `bson:"variant_sku,omitempty" json:"variant_sku,omitempty"`
+	VariantOptions map[string]string `bson:"variant_options,omitempty" json:"variant_options,omitempty"`
+}
+
+// SplitSaleItems separates items already on sale from those that may still
+// be discounted, returning the total of the sale items.
+func SplitSaleItems(items []CartItem) ([]CartItem, Money) {
+	eligible := make([]CartItem, 0, len(items))
+	var saleTotal Money
+	for _, item := range items {
+		if item.OnSale {
+			saleTotal += item.TotalPrice
+			continue
+		}
+		eligible = append(eligible, item)
+	}
+	return eligible, saleTotal
 }
 
 type CartItemRepository interface {
 	Create(ctx context.Context, cartItem *CartItem) error
 	FindByCartID(ctx context.Context, cartID string) ([]CartItem, error)
 	FindByID(ctx context.Context, id string) (*CartItem, error)
-	FindAll(ctx context.Context) ([]CartItem, error)
+	FindAll(ctx context.Context, query ListQuery) ([]CartItem, *Pagination, error)
 	Update(ctx context.Context, cartItem *CartItem) error
 	Delete(ctx context.Context, id string) error
 }
@@ -37,7 +60,7 @@ type CartItemUseCase interface {
 	Create(ctx context.Context, cartItem *CartItem) error
 	GetByCartID(ctx context.Context, cartID string) ([]CartItem, error)
 	GetByID(ctx context.Context, id string) (*CartItem, error)
-	GetAll(ctx context.Context) ([]CartItem, error)
+	GetAll(ctx context.Context, query ListQuery) ([]CartItem, *Pagination, error)
 	Update(ctx context.Context, cartItem *CartItem) error
 	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+type TransferFormat string
+
+const (
+	TransferFormatCSV   TransferFormat = "csv"
+	TransferFormatJSONL TransferFormat = "jsonl"
+)
+
+func ParseTransferFormat(s string) (TransferFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "csv", "text/csv":
+		return TransferFormatCSV, nil
+	case "jsonl", "ndjson", "application/x-ndjson", "application/jsonl":
+		return TransferFormatJSONL, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+func (f TransferFormat) ContentType() string {
+	if f == TransferFormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+type CatalogTransferUseCase interface {
+	ImportProducts(ctx context.Context, format TransferFormat, r io.Reader, dryRun bool) (*ImportResult, error)
+	ExportProducts(ctx context.Context, format TransferFormat, w io.Writer) error
+	ExportCategories(ctx context.Context, format TransferFormat, w io.Writer) error
+}
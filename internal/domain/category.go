@@ -2,31 +2,83 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const CategoryRootPath = ","
+
 type Category struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Name        string             `bson:"name" json:"name" validate:"required"`
-	Description string             `bson:"description" json:"description" validate:"required"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID  `bson:"_id,omitempty"`
+	Slug          string              `bson:"slug,omitempty" json:"slug"`
+	PreviousSlugs []string            `bson:"previous_slugs,omitempty" json:"-"`
+	Name          string              `bson:"name" json:"name" validate:"required"`
+	Description   string              `bson:"description" json:"description" validate:"required"`
+	ParentID      *primitive.ObjectID `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	Path          string              `bson:"path" json:"path"`
+	Depth         int                 `bson:"depth" json:"depth"`
+	CreatedAt     time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time           `bson:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time          `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+}
+
+type CategoryNode struct {
+	Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// SubtreePath is the materialised path prefix shared by every descendant of c.
+func (c *Category) SubtreePath() string {
+	path := c.Path
+	if path == "" {
+		path = CategoryRootPath
+	}
+	return path + c.ID.Hex() + ","
+}
+
+func (c *Category) AncestorIDs() []primitive.ObjectID {
+	var ids []primitive.ObjectID
+	for _, hex := range strings.Split(strings.Trim(c.Path, ","), ",") {
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
 type CategoryRepository interface {
 	Create(ctx context.Context, category *Category) error
 	FindByID(ctx context.Context, id string) (*Category, error)
-	FindAll(ctx context.Context) ([]Category, error)
+	FindByName(ctx context.Context, name string) (*Category, error)
+	FindBySlug(ctx context.Context, slug string) (*Category, error)
+	SlugTaken(ctx context.Context, slug string, exclude primitive.ObjectID) (bool, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]Category, error)
+	FindByPathPrefix(ctx context.Context, prefix string) ([]Category, error)
+	HasChildren(ctx context.Context, id string) (bool, error)
+	FindAll(ctx context.Context, query ListQuery) ([]Category, *Pagination, error)
 	Update(ctx context.Context, category *Category) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	FindArchived(ctx context.Context, query ListQuery) ([]Category, *Pagination, error)
+	FindArchivedByID(ctx context.Context, id string) (*Category, error)
+	ArchivePurger
+	Each(ctx context.Context, fn func(*Category) error) error
 }
 
 type CategoryUseCase interface {
 	Create(ctx context.Context, category *Category) error
 	GetByID(ctx context.Context, id string) (*Category, error)
-	GetAll(ctx context.Context) ([]Category, error)
+	GetBySlug(ctx context.Context, slug string) (*Category, error)
+	GetAll(ctx context.Context, query ListQuery) ([]Category, *Pagination, error)
+	EnsureSlugs(ctx context.Context) (int, error)
+	EnsurePaths(ctx context.Context) (int, error)
+	GetTree(ctx context.Context) ([]*CategoryNode, error)
+	GetSubtree(ctx context.Context, id string) (*CategoryNode, error)
+	GetBreadcrumbs(ctx context.Context, id string) ([]Category, error)
 	Update(ctx context.Context, category *Category) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*Category, error)
+	GetArchived(ctx context.Context, query ListQuery) ([]Category, *Pagination, error)
 }
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Currency is an ISO 4217 code such as "THB".
+type Currency string
+
+// BaseCurrency is the currency catalogue prices, campaign budgets and
+// exchange rates are expressed in.
+const BaseCurrency Currency = "THB"
+
+// otherMinorUnits lists the ISO 4217 currencies that don't have two decimal
+// places, with the number they do have.
+var otherMinorUnits = map[Currency]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Valid accepts upper case three-letter codes of currencies with two decimal
+// places. Money counts every amount in hundredths, so a currency with more or
+// fewer would parse, print and convert wrongly.
+func (c Currency) Valid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	_, other := otherMinorUnits[c]
+	return !other
+}
+
+// OrBase reads an unset currency, as on documents from before currencies, as
+// the base currency.
+func (c Currency) OrBase() Currency {
+	if c == "" {
+		return BaseCurrency
+	}
+	return c
+}
+
+// ExchangeRate is how many units of Currency one unit of BaseCurrency buys.
+type ExchangeRate struct {
+	Currency  Currency  `bson:"_id" json:"currency"`
+	Rate      float64   `bson:"rate" json:"rate" validate:"required,gt=0"`
+	UpdatedBy string    `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Exchange converts m from a currency at fromRate to one at toRate, both per
+// unit of BaseCurrency, rounding with mode.
+func (m Money) Exchange(fromRate, toRate float64, mode RoundingMode) Money {
+	from, ok := new(big.Rat).SetString(strconv.FormatFloat(fromRate, 'f', -1, 64))
+	if !ok || from.Sign() <= 0 {
+		return 0
+	}
+	to, ok := new(big.Rat).SetString(strconv.FormatFloat(toRate, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+	r := new(big.Rat).Mul(big.NewRat(int64(m), 1), to)
+	return roundRat(r.Quo(r, from), mode)
+}
+
+type ExchangeRateRepository interface {
+	FindAll(ctx context.Context) ([]ExchangeRate, error)
+	FindByCurrency(ctx context.Context, currency Currency) (*ExchangeRate, error)
+	Upsert(ctx context.Context, rate *ExchangeRate) error
+	Delete(ctx context.Context, currency Currency) error
+}
+
+type ExchangeRateUseCase interface {
+	GetAll(ctx context.Context) ([]ExchangeRate, error)
+	Set(ctx context.Context, rate *ExchangeRate) error
+	Delete(ctx context.Context, currency string) error
+	// Supported reports whether amounts can be converted into currency.
+	Supported(ctx context.Context, currency Currency) (bool, error)
+	Convert(ctx context.Context, amount Money, from, to Currency) (Money, error)
+}
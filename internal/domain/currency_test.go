@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrency_Valid(t *testing.T) {
+	assert.True(t, Currency("USD").Valid())
+	assert.False(t, Currency("usd").Valid())
+	assert.False(t, Currency("US").Valid())
+	assert.False(t, Currency("").Valid())
+	assert.False(t, Currency("JPY").Valid(), "no minor unit")
+	assert.False(t, Currency("KWD").Valid(), "three decimal places")
+	assert.Equal(t, BaseCurrency, Currency("").OrBase())
+}
+
+func TestMoney_Exchange(t *testing.T) {
+	// 1 THB buys 0.028 USD and 0.026 EUR.
+	assert.Equal(t, Money(28_00), Money(1000_00).Exchange(1, 0.028, RoundHalfEven))
+	assert.Equal(t, Money(1000_00), Money(28_00).Exchange(0.028, 1, RoundHalfEven))
+	assert.Equal(t, Money(93), Money(1_00).Exchange(0.028, 0.026, RoundHalfEven))
+	assert.Equal(t, Money(0), Money(1_00).Exchange(0, 1, RoundHalfEven))
+}
+
+func TestDiscountRule_AppliesTo(t *testing.T) {
+	percentage := DiscountRule{DiscuntType: DiscountTypePercentage}
+	assert.True(t, percentage.AppliesTo("USD"))
+
+	fixed := DiscountRule{DiscuntType: DiscountTypeFixedAmount}
+	assert.True(t, fixed.AppliesTo(""))
+	assert.True(t, fixed.AppliesTo(BaseCurrency))
+	assert.False(t, fixed.AppliesTo("USD"))
+
+	fixed.Currency = "USD"
+	assert.True(t, fixed.AppliesTo("USD"))
+	assert.False(t, fixed.AppliesTo(BaseCurrency))
+}
+
+func TestProduct_ListPrice(t *testing.T) {
+	variantPrice := Money(12_00)
+	product := Product{Prices: map[Currency]Money{"USD": 9_99}}
+
+	price, ok := product.ListPrice("USD", nil)
+	assert.True(t, ok)
+	assert.Equal(t, Money(9_99), price)
+
+	_, ok = product.ListPrice("EUR", nil)
+	assert.False(t, ok)
+
+	_, ok = product.ListPrice("USD", &ProductVariant{Price: &variantPrice})
+	assert.False(t, ok)
+
+	assert.NoError(t, product.ValidatePrices())
+	product.Prices[BaseCurrency] = 100_00
+	assert.ErrorIs(t, product.ValidatePrices(), ErrInvalidPriceList)
+}
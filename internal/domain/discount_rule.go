@@ -7,27 +7,139 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	DiscountTypeFixedAmount = "fixed_amount"
+	DiscountTypePercentage  = "percentage"
+	DiscountTypeCategory    = "category"
+	DiscountTypeVariant     = "variant"
+	DiscountTypeSpecial     = "special"
+	DiscountTypeBOGO        = "bogo"
+	DiscountTypeBuyXGetY    = "buy_x_get_y"
+	DiscountTypeBundle      = "bundle"
+	DiscountTypeTiered      = "tiered"
+)
+
+func ValidDiscountType(t string) bool {
+	switch t {
+	case DiscountTypeFixedAmount, DiscountTypePercentage, DiscountTypeCategory, DiscountTypeVariant, DiscountTypeSpecial,
+		DiscountTypeBOGO, DiscountTypeBuyXGetY, DiscountTypeBundle, DiscountTypeTiered:
+		return true
+	}
+	return false
+}
+
 type DiscountRule struct {
 	ID                          primitive.ObjectID `bson:"_id,omitempty"`
 	CampaignID                  primitive.ObjectID `bson:"campaign_id,omitempty" json:"campaign_id" validate:"required"`
 	DiscuntType                 string             `bson:"discount_type,omitempty" json:"discount_type" validate:"required"`
-	Amount                      float64            `bson:"amount,omitempty" json:"amount" validate:"required"`
+	Amount                      Money              `bson:"amount,omitempty" json:"amount" validate:"required"`
 	Percentage                  float64            `bson:"percentage,omitempty" json:"percentage" validate:"required"`
 	ItemCategory                string             `bson:"item_category,omitempty" json:"item_category" validate:"required"`
+	ItemSKU                     string             `bson:"item_sku,omitempty" json:"item_sku,omitempty"`
 	PointsRatio                 float64            `bson:"points_ratio,omitempty" json:"points_ratio" validate:"required"`
 	MaxDiscountPercentage       float64            `bson:"max_discount_percentage,omitempty" json:"max_discount_percentage" validate:"required"`
-	ThresholdAmount             float64            `bson:"threshold_amount,omitempty" json:"threshold_amount" validate:"required"`
+	ThresholdAmount             Money              `bson:"threshold_amount,omitempty" json:"threshold_amount" validate:"required"`
 	DiscountPercentageThreshold float64            `bson:"discount_percentage_threshold,omitempty" json:"discount_percentage_threshold" validate:"required"`
-	CreatedAt                   time.Time          `bson:"created_at,omitempty" json:"created_at"`
-	UpdatedAt                   time.Time          `bson:"updated_at,omitempty" json:"updated_at"`
+	ExcludeSaleItems            bool               `bson:"exclude_sale_items,omitempty" json:"exclude_sale_items"`
+	// Currency is what the rule's fixed amounts and spend tiers are in, the
+	// base currency when unset. Such a rule only applies to carts in its
+	// currency, so each currency gets a rule of its own.
+	Currency Currency `bson:"currency,omitempty" json:"currency,omitempty"`
+	// Condition limits the rule to carts, customers or lines matching an
+	// expression such as `cart.subtotal >= 500 && item.category in ["Shoes"]`.
+	Condition string `bson:"condition,omitempty" json:"condition,omitempty"`
+
+	// Buy X get Y and BOGO. Percentage is taken off the rewarded items and
+	// defaults to 100; the get target defaults to the buy target.
+	BuyQuantity   int                  `bson:"buy_quantity,omitempty" json:"buy_quantity,omitempty"`
+	BuyProductIDs []primitive.ObjectID `bson:"buy_product_ids,omitempty" json:"buy_product_ids,omitempty"`
+	BuyCategory   string               `bson:"buy_category,omitempty" json:"buy_category,omitempty"`
+	GetQuantity   int                  `bson:"get_quantity,omitempty" json:"get_quantity,omitempty"`
+	GetProductIDs []primitive.ObjectID `bson:"get_product_ids,omitempty" json:"get_product_ids,omitempty"`
+	GetCategory   string               `bson:"get_category,omitempty" json:"get_category,omitempty"`
+
+	BundleProductIDs []primitive.ObjectID `bson:"bundle_product_ids,omitempty" json:"bundle_product_ids,omitempty"`
+	BundlePrice      Money                `bson:"bundle_price,omitempty" json:"bundle_price,omitempty"`
+
+	// Tiered rules count quantity or spend over ItemCategory, or the whole
+	// cart when it is empty.
+	TierBasis TierBasis      `bson:"tier_basis,omitempty" json:"tier_basis,omitempty"`
+	Tiers     []DiscountTier `bson:"tiers,omitempty" json:"tiers,omitempty"`
+
+	CreatedAt time.Time `bson:"created_at,omitempty" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at,omitempty" json:"updated_at"`
+
+	CampaignName      string `bson:"campaign_name,omitempty" json:"campaign_name"`
+	CampaignPriority  int    `bson:"campaign_priority,omitempty" json:"-"`
+	CampaignExclusive bool   `bson:"campaign_exclusive,omitempty" json:"-"`
+
+	CampaignSegmentIDs []primitive.ObjectID `bson:"campaign_segment_ids,omitempty" json:"-"`
+
+	Conflicts []CampaignConflict `bson:"-" json:"conflicts,omitempty"`
+}
+
+// DiscountLine is one rule's contribution to a cart's discount.
+type DiscountLine struct {
+	RuleID       primitive.ObjectID `bson:"rule_id" json:"rule_id"`
+	CampaignID   primitive.ObjectID `bson:"campaign_id" json:"campaign_id"`
+	CampaignName string             `bson:"campaign_name" json:"campaign_name"`
+	DiscountType string             `bson:"discount_type" json:"discount_type"`
+	Amount       Money              `bson:"amount" json:"amount"`
+	// Items allocates the amount to cart lines, for the types that discount
+	// particular units.
+	Items []DiscountAllocation `bson:"items,omitempty" json:"items,omitempty"`
+}
+
+// ScaledTo shrinks the line to amount, splitting it over its allocations in
+// proportion to what each carried.
+func (l DiscountLine) ScaledTo(amount Money) DiscountLine {
+	l.Amount = amount
+	if l.Items != nil {
+		l.Items = ScaleAllocations(l.Items, amount)
+	}
+	return l
+}
+
+// AppliesTo reports whether the rule can discount a cart in currency. Rules
+// that only take percentages off apply in every currency.
+func (r *DiscountRule) AppliesTo(currency Currency) bool {
+	return !r.hasFixedAmounts() || r.Currency.OrBase() == currency.OrBase()
+}
+
+func (r *DiscountRule) hasFixedAmounts() bool {
+	switch r.DiscuntType {
+	case DiscountTypeFixedAmount, DiscountTypeSpecial, DiscountTypeBundle:
+		return true
+	case DiscountTypeTiered:
+		if r.TierBasis == TierBasisSpend {
+			return true
+		}
+		for _, tier := range r.Tiers {
+			if tier.Amount > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	CampaignName string `bson:"campaign_name,omitempty" json:"campaign_name"`
+type DiscountEvaluation struct {
+	Currency Currency       `json:"currency"`
+	Subtotal Money          `json:"subtotal"`
+	Discount Money          `json:"discount"`
+	Tax      *TaxBreakdown  `json:"tax,omitempty"`
+	Total    Money          `json:"total"`
+	Lines    []DiscountLine `json:"lines"`
 }
 
 type DiscountRuleRepository interface {
 	Create(ctx context.Context, discountRule *DiscountRule) error
 	FindByID(ctx context.Context, id string) (*DiscountRule, error)
-	FindAll(ctx context.Context) ([]DiscountRule, error)
+	FindAll(ctx context.Context, query ListQuery) ([]DiscountRule, *Pagination, error)
+	// FindLive returns the rules of campaigns that are active and inside
+	// their window at the given time.
+	FindLive(ctx context.Context, at time.Time) ([]DiscountRule, error)
+	FindByCampaignIDs(ctx context.Context, campaignIDs []primitive.ObjectID) ([]DiscountRule, error)
 	Update(ctx context.Context, discountRule *DiscountRule) error
 	Delete(ctx context.Context, id string) error
 }
@@ -35,7 +147,11 @@ type DiscountRuleRepository interface {
 type DiscountRuleUseCase interface {
 	Create(ctx context.Context, discountRule *DiscountRule) error
 	GetByID(ctx context.Context, id string) (*DiscountRule, error)
-	GetAll(ctx context.Context) ([]DiscountRule, error)
+	GetAll(ctx context.Context, query ListQuery) ([]DiscountRule, *Pagination, error)
 	Update(ctx context.Context, discountRule *DiscountRule) error
 	Delete(ctx context.Context, id string) error
+	// Evaluate prices the cart for customerID, who must belong to a campaign's
+	// segments for it to apply. A zero customerID only sees untargeted
+	// campaigns.
+	Evaluate(ctx context.Context, customerID primitive.ObjectID, currency Currency, cartItems []CartItem, at time.Time) (*DiscountEvaluation, error)
 }
@@ -9,9 +9,19 @@ var (
 	ErrBadRequest          = errors.New("invalid request")
 	ErrUnauthorized        = errors.New("unauthorized access")
 
-	ErrCategoryNotFound      = errors.New("category not found")
-	ErrCategoryAlreadyExists = errors.New("category already exists")
-	ErrInvalidCategoryID     = errors.New("invalid category ID")
+	ErrInvalidSortField = errors.New("invalid sort field")
+	ErrInvalidCursor    = errors.New("invalid pagination cursor")
+	ErrInvalidListQuery = errors.New("invalid list query")
+
+	ErrInvalidSlug = errors.New("slug must contain at least one letter or digit")
+	ErrSlugTaken   = errors.New("slug is already in use")
+
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrCategoryAlreadyExists  = errors.New("category already exists")
+	ErrInvalidCategoryID      = errors.New("invalid category ID")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrCategoryCycle          = errors.New("category cannot be moved under itself or its descendants")
+	ErrCategoryHasChildren    = errors.New("category has child categories")
 
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
@@ -25,25 +35,97 @@ var (
 	ErrProductAlreadyExists = errors.New("product already exists")
 	ErrInvalidProductID     = errors.New("invalid product ID")
 	ErrInvalidProductData   = errors.New("invalid product data")
+	ErrEmptySearchQuery     = errors.New("search query cannot be empty")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+
+	ErrInvalidMovementType  = errors.New("stock movement type must be restock, sale, return or correction")
+	ErrInvalidStockQuantity = errors.New("stock quantity must be greater than 0")
+	ErrStockChanged         = errors.New("stock changed while it was being counted, try again")
+
+	ErrVariantNotFound  = errors.New("product variant not found")
+	ErrInvalidVariantID = errors.New("invalid product variant ID")
+	ErrVariantRequired  = errors.New("product variant must be selected")
+	ErrDuplicateSKU     = errors.New("SKU already exists")
+
+	ErrPriceChangeNotFound     = errors.New("price change not found")
+	ErrInvalidPriceChangeID    = errors.New("invalid price change ID")
+	ErrPriceChangeNotScheduled = errors.New("price change is no longer scheduled")
+
+	ErrImageNotFound        = errors.New("product image not found")
+	ErrImageTooLarge        = errors.New("image exceeds the maximum upload size")
+	ErrImageTooManyPixels   = errors.New("image dimensions exceed the maximum pixel count")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrInvalidBlobKey       = errors.New("invalid storage key")
+
+	ErrUnsupportedFormat = errors.New("unsupported format, expected csv or jsonl")
+	ErrInvalidImportFile = errors.New("import file is missing a header or required columns")
+
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrInvalidReviewID     = errors.New("invalid review ID")
+	ErrReviewAlreadyExists = errors.New("you have already reviewed this product")
+	ErrReviewNotPurchased  = errors.New("only customers who bought this product can review it")
+	ErrReviewForbidden     = errors.New("review belongs to another user")
+	ErrInvalidReviewStatus = errors.New("review status must be published or hidden")
+
+	ErrWishlistItemNotFound = errors.New("wishlist item not found")
+	ErrInvalidWishlistID    = errors.New("invalid wishlist item ID")
+	ErrWishlistItemExists   = errors.New("product is already in your wishlist")
+
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrInvalidNotificationID = errors.New("invalid notification ID")
+
+	ErrCampaignNotFound       = errors.New("campaign not found")
+	ErrCampaignAlreadyExists  = errors.New("campaign already exists")
+	ErrInvalidCampaignID      = errors.New("invalid campaign ID")
+	ErrInvalidCampaignData    = errors.New("invalid campaign data")
+	ErrInvalidCampaignWindow  = errors.New("campaign end date must be after its start date")
+	ErrCampaignConflict       = errors.New("campaign conflicts with an overlapping exclusive campaign of the same priority")
+	ErrInvalidCampaignLimits  = errors.New("campaign budget and redemption limits cannot be negative")
+	ErrInvalidStatsRange      = errors.New("stats range must start before it ends")
+	ErrInvalidSimulation      = errors.New("simulation needs at least one discount rule of a supported type")
+	ErrInvalidSimulationRange = errors.New("simulation range must start before it ends")
+
+	ErrSegmentNotFound    = errors.New("segment not found")
+	ErrInvalidSegmentID   = errors.New("invalid segment ID")
+	ErrInvalidSegmentRule = errors.New("invalid segment rule")
+	ErrSegmentInUse       = errors.New("segment is still targeted by a campaign")
+	ErrSegmentExists      = errors.New("segment name already exists")
+
+	ErrInvalidCurrency      = errors.New("currency must be a three-letter ISO 4217 code with two decimal places")
+	ErrUnsupportedCurrency  = errors.New("currency has no exchange rate")
+	ErrInvalidPriceList     = errors.New("price list needs a positive price for each currency other than the base currency")
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+	ErrBaseCurrencyRate     = errors.New("the base currency always has a rate of 1")
+
+	ErrInvalidTaxClass      = errors.New("tax class must be standard, reduced, zero or exempt")
+	ErrInvalidTaxRegion     = errors.New("tax region needs an upper case code and rates between 0 and 100 for taxed classes")
+	ErrTaxRegionNotFound    = errors.New("tax region not found")
+	ErrUnsupportedTaxRegion = errors.New("tax region is not configured")
 
-	ErrCampaignNotFound      = errors.New("campaign not found")
-	ErrCampaignAlreadyExists = errors.New("campaign already exists")
-	ErrInvalidCampaignID     = errors.New("invalid campaign ID")
-	ErrInvalidCampaignData   = errors.New("invalid campaign data")
+	ErrOrderNotFound  = errors.New("order not found")
+	ErrInvalidOrderID = errors.New("invalid order ID")
 
 	ErrCartNotFound  = errors.New("cart not found")
 	ErrInvalidCartID = errors.New("invalid cart ID")
 
-	ErrCartItemNotFound  = errors.New("cart item not found")
-	ErrInvalidCartItemID = errors.New("invalid cart item ID")
+	ErrCartItemNotFound       = errors.New("cart item not found")
+	ErrInvalidCartItemID      = errors.New("invalid cart item ID")
+	ErrCartItemProductChanged = errors.New("cart item product cannot be changed, remove it and add the new product instead")
 
 	ErrInvalidDiscountRuleID = errors.New("invalid discount rule ID")
 	ErrDiscountRuleNotFound  = errors.New("discount rule not found")
+	ErrInvalidDiscountType   = errors.New("unsupported discount type")
 
+	ErrInvalidMoney              = errors.New("invalid money amount")
 	ErrEmptyCart                 = errors.New("cart is empty")
 	ErrInvalidDiscountAmount     = errors.New("discount amount must be greater than 0")
 	ErrInvalidDiscountPercentage = errors.New("discount percentage must be between 0 and 100")
 	ErrInvalidCategory           = errors.New("category cannot be empty")
+	ErrInvalidSKU                = errors.New("SKU cannot be empty")
 	ErrInvalidPoints             = errors.New("points must be greater than 0")
 	ErrInvalidThreshold          = errors.New("threshold amount must be greater than 0")
+	ErrInvalidOffer              = errors.New("buy and get quantities must be greater than 0 and the offer needs items to apply to")
+	ErrInvalidBundle             = errors.New("bundle needs at least one product and a price greater than 0")
+	ErrInvalidCondition          = errors.New("invalid discount condition")
+	ErrInvalidTiers              = errors.New("tiers must share a basis and kind, with thresholds and discounts that both increase")
 )
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	EventProductLowStock     = "product.low_stock"
+	EventProductBackInStock  = "product.back_in_stock"
+	EventProductPriceChanged = "product.price_changed"
+	EventCampaignStarted     = "campaign.started"
+	EventCampaignEnded       = "campaign.ended"
+	EventCampaignExhausted   = "campaign.exhausted"
+	EventOrderPlaced         = "order.placed"
+)
+
+type Event struct {
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Payload    interface{} `json:"payload"`
+}
+
+func NewEvent(eventType string, payload interface{}) Event {
+	return Event{Type: eventType, OccurredAt: time.Now(), Payload: payload}
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
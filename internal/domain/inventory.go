@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StockMovementType string
+
+const (
+	StockMovementRestock    StockMovementType = "restock"
+	StockMovementSale       StockMovementType = "sale"
+	StockMovementReturn     StockMovementType = "return"
+	StockMovementCorrection StockMovementType = "correction"
+)
+
+func (t StockMovementType) Valid() bool {
+	switch t {
+	case StockMovementRestock, StockMovementSale, StockMovementReturn, StockMovementCorrection:
+		return true
+	}
+	return false
+}
+
+// Actor identifies who caused a change, for audit records.
+type Actor struct {
+	ID    string
+	Email string
+}
+
+type StockMovement struct {
+	ID          primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	ProductID   primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	VariantID   *primitive.ObjectID `bson:"variant_id,omitempty" json:"variant_id,omitempty"`
+	Type        StockMovementType   `bson:"type" json:"type"`
+	Quantity    int                 `bson:"quantity" json:"quantity"`
+	StockBefore int                 `bson:"stock_before" json:"stock_before"`
+	StockAfter  int                 `bson:"stock_after" json:"stock_after"`
+	Reason      string              `bson:"reason" json:"reason"`
+	Reference   string              `bson:"reference,omitempty" json:"reference,omitempty"`
+	ActorID     string              `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	ActorEmail  string              `bson:"actor_email,omitempty" json:"actor_email,omitempty"`
+	CreatedAt   time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// StockAdjustment is a requested change. Restock, sale and return move stock
+// by Quantity; a correction sets the counted stock level to Quantity.
+type StockAdjustment struct {
+	VariantID string            `json:"variant_id"`
+	Type      StockMovementType `json:"type" validate:"required"`
+	Quantity  int               `json:"quantity" validate:"min=0"`
+	Reason    string            `json:"reason" validate:"required,max=500"`
+	Reference string            `json:"reference" validate:"max=120"`
+}
+
+type LowStockAlert struct {
+	ProductID   primitive.ObjectID  `json:"product_id"`
+	VariantID   *primitive.ObjectID `json:"variant_id,omitempty"`
+	ProductName string              `json:"product_name"`
+	SKU         string              `json:"sku,omitempty"`
+	Stock       int                 `json:"stock"`
+	Threshold   int                 `json:"threshold"`
+}
+
+type BackInStock struct {
+	ProductID   primitive.ObjectID  `json:"product_id"`
+	VariantID   *primitive.ObjectID `json:"variant_id,omitempty"`
+	ProductName string              `json:"product_name"`
+	Stock       int                 `json:"stock"`
+}
+
+type StockMovementRepository interface {
+	Create(ctx context.Context, movement *StockMovement) error
+	FindByProductID(ctx context.Context, productID primitive.ObjectID, query ListQuery) ([]StockMovement, *Pagination, error)
+}
+
+type InventoryUseCase interface {
+	Adjust(ctx context.Context, productID string, actor Actor, adjustment StockAdjustment) (*StockMovement, error)
+	GetMovements(ctx context.Context, productID string, query ListQuery) ([]StockMovement, *Pagination, error)
+	GetLowStock(ctx context.Context, query ListQuery) ([]Product, *Pagination, error)
+}
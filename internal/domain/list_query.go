@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type ListFilter struct {
+	MinPrice    *Money
+	MaxPrice    *Money
+	Category    string
+	CategoryID  string
+	CategoryIDs []primitive.ObjectID
+	// IncludeDescendants widens CategoryID to its subcategories unless set
+	// to false.
+	IncludeDescendants *bool
+	InStock            *bool
+	Status             string
+	Type               string
+	CreatedFrom        *time.Time
+	CreatedTo          *time.Time
+}
+
+type ListQuery struct {
+	Page   int
+	Limit  int
+	Cursor string
+	Sort   []SortField
+	Filter ListFilter
+}
+
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	HasNext    bool   `json:"has_next"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func (q *ListQuery) Normalize() {
+	if q.Limit <= 0 {
+		q.Limit = DefaultListLimit
+	}
+	if q.Limit > MaxListLimit {
+		q.Limit = MaxListLimit
+	}
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+}
+
+func (q *ListQuery) UsesCursor() bool {
+	return q.Cursor != ""
+}
@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Money is an amount in minor units (satang), so sums and comparisons are
+// exact. It reads and writes JSON as a decimal number and is stored as
+// Decimal128 in major units, which sorts and aggregates alongside the doubles
+// older documents hold.
+type Money int64
+
+const minorPerMajor = 100
+
+type RoundingMode string
+
+const (
+	RoundHalfEven RoundingMode = "half_even"
+	RoundHalfUp   RoundingMode = "half_up"
+)
+
+// RoundingScope decides where percentage discounts are rounded: once on the
+// total of the lines, which is then allocated back to them, or on each line.
+type RoundingScope string
+
+const (
+	RoundPerOrder RoundingScope = "per_order"
+	RoundPerLine  RoundingScope = "per_line"
+)
+
+type Rounding struct {
+	Mode  RoundingMode
+	Scope RoundingScope
+}
+
+func (r Rounding) Valid() bool {
+	return (r.Mode == RoundHalfEven || r.Mode == RoundHalfUp) && (r.Scope == RoundPerOrder || r.Scope == RoundPerLine)
+}
+
+// ParseMoney reads a decimal amount in major units such as "1299.50". Digits
+// past the minor unit are rounded half to even.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.Contains(s, "/") {
+		return 0, ErrInvalidMoney
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidMoney
+	}
+	return roundRat(r.Mul(r, big.NewRat(minorPerMajor, 1)), RoundHalfEven), nil
+}
+
+// MoneyFromFloat converts a float amount in major units, rounding half to
+// even. Floats are read by their shortest decimal form, so 0.1+0.2 is 0.30.
+func MoneyFromFloat(f float64) Money {
+	m, err := ParseMoney(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return 0
+	}
+	return m
+}
+
+// Float64 is for ratios and display only; never do arithmetic on it.
+func (m Money) Float64() float64 {
+	return float64(m) / minorPerMajor
+}
+
+func (m Money) String() string {
+	sign := ""
+	minor := int64(m)
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorPerMajor, minor%minorPerMajor)
+}
+
+func (m Money) Times(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Divide splits m into n equal parts, rounded with mode.
+func (m Money) Divide(n int64, mode RoundingMode) Money {
+	return roundRat(big.NewRat(int64(m), n), mode)
+}
+
+// Percent returns percentage of m, rounded with mode.
+func (m Money) Percent(percentage float64, mode RoundingMode) Money {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(percentage, 'f', -1, 64))
+	if !ok {
+		return 0
+	}
+	r.Mul(r, big.NewRat(int64(m), 100))
+	return roundRat(r, mode)
+}
+
+// Percent takes percentage off each of bases. Per order, the total is rounded
+// once and allocated back to the bases; per line, each is rounded alone.
+func (r Rounding) Percent(bases []Money, percentage float64) []Money {
+	if r.Scope == RoundPerLine {
+		parts := make([]Money, len(bases))
+		for i, base := range bases {
+			parts[i] = base.Percent(percentage, r.Mode)
+		}
+		return parts
+	}
+
+	var total Money
+	for _, base := range bases {
+		total += base
+	}
+	return Allocate(total.Percent(percentage, r.Mode), bases)
+}
+
+func MinMoney(a, b Money) Money {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func MaxMoney(a, b Money) Money {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Allocate splits total across the weights in proportion. The minor units
+// lost to rounding down go to the largest remainders, earliest first, so the
+// parts always add up to total. Without any positive weight it splits
+// evenly.
+func Allocate(total Money, weights []Money) []Money {
+	parts := make([]Money, len(weights))
+	if len(weights) == 0 {
+		return parts
+	}
+
+	sign := Money(1)
+	if total < 0 {
+		sign, total = -1, -total
+	}
+
+	adjusted := make([]int64, len(weights))
+	var sum int64
+	for i, weight := range weights {
+		if weight > 0 {
+			adjusted[i] = int64(weight)
+			sum += int64(weight)
+		}
+	}
+	if sum == 0 {
+		for i := range adjusted {
+			adjusted[i] = 1
+		}
+		sum = int64(len(adjusted))
+	}
+
+	remainders := make([]int64, len(weights))
+	allocated := Money(0)
+	for i, weight := range adjusted {
+		share, remainder := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(int64(total)), big.NewInt(weight)),
+			big.NewInt(sum),
+			new(big.Int),
+		)
+		parts[i] = Money(share.Int64())
+		remainders[i] = remainder.Int64()
+		allocated += parts[i]
+	}
+
+	for left := total - allocated; left > 0; left-- {
+		largest := -1
+		for i, remainder := range remainders {
+			if adjusted[i] > 0 && (largest < 0 || remainder > remainders[largest]) {
+				largest = i
+			}
+		}
+		parts[largest]++
+		remainders[largest] = -1
+	}
+
+	for i := range parts {
+		parts[i] *= sign
+	}
+	return parts
+}
+
+func roundRat(r *big.Rat, mode RoundingMode) Money {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	twice := new(big.Int).Lsh(new(big.Int).Abs(remainder), 1)
+	switch cmp := twice.Cmp(r.Denom()); {
+	case cmp > 0, cmp == 0 && (mode == RoundHalfUp || quotient.Bit(0) == 1):
+		if r.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return Money(quotient.Int64())
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	d, err := primitive.ParseDecimal128(m.String())
+	if err != nil {
+		return 0, nil, err
+	}
+	return bson.MarshalValue(d)
+}
+
+// UnmarshalBSONValue also reads the doubles and integers that amounts were
+// stored as before Money.
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.Decimal128:
+		parsed, err := ParseMoney(value.Decimal128().String())
+		if err != nil {
+			return err
+		}
+		*m = parsed
+	case bsontype.Double:
+		*m = MoneyFromFloat(value.Double())
+	case bsontype.Int32:
+		*m = Money(value.Int32()) * minorPerMajor
+	case bsontype.Int64:
+		*m = Money(value.Int64()) * minorPerMajor
+	case bsontype.Null, bsontype.Undefined:
+		*m = 0
+	default:
+		return fmt.Errorf("%w: cannot decode %s", ErrInvalidMoney, t)
+	}
+	return nil
+}
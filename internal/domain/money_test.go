@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := map[string]Money{
+		"1299.50": 129950,
+		"0.1":     10,
+		"-3":      -300,
+		"2.345":   234,
+		"2.355":   236,
+		"1e3":     100000,
+	}
+	for input, want := range tests {
+		got, err := ParseMoney(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "abc", "1/3"} {
+		_, err := ParseMoney(input)
+		assert.ErrorIs(t, err, ErrInvalidMoney, input)
+	}
+}
+
+func TestMoneyFromFloat_UsesShortestDecimal(t *testing.T) {
+	assert.Equal(t, Money(30), MoneyFromFloat(0.1+0.2))
+	assert.Equal(t, Money(199), MoneyFromFloat(1.99))
+}
+
+func TestMoney_PercentRounding(t *testing.T) {
+	price := Money(1050)
+
+	assert.Equal(t, Money(52), price.Percent(5, RoundHalfEven))
+	assert.Equal(t, Money(53), price.Percent(5, RoundHalfUp))
+	assert.Equal(t, Money(-52), (-price).Percent(5, RoundHalfEven))
+	assert.Equal(t, Money(350), price.Percent(33.333333333333336, RoundHalfEven))
+}
+
+func TestRounding_PercentScope(t *testing.T) {
+	lines := []Money{1_05, 1_05, 1_05}
+
+	perOrder := Rounding{Mode: RoundHalfUp, Scope: RoundPerOrder}.Percent(lines, 10)
+	perLine := Rounding{Mode: RoundHalfUp, Scope: RoundPerLine}.Percent(lines, 10)
+
+	assert.Equal(t, []Money{11, 11, 10}, perOrder)
+	assert.Equal(t, []Money{11, 11, 11}, perLine)
+}
+
+func TestAllocate_LargestRemainder(t *testing.T) {
+	assert.Equal(t, []Money{34, 33, 33}, Allocate(100, []Money{1, 1, 1}))
+	assert.Equal(t, []Money{50, 0, 50}, Allocate(100, []Money{300, 0, 300}))
+	assert.Equal(t, []Money{667, 333}, Allocate(1000, []Money{200, 100}))
+	assert.Equal(t, []Money{-34, -33, -33}, Allocate(-100, []Money{0, 0, 0}))
+
+	parts := Allocate(1001, []Money{333, 333, 334})
+	assert.Equal(t, Money(1001), parts[0]+parts[1]+parts[2])
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Price Money `json:"price"`
+	}{Price: 129950})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"price": 1299.50}`, string(data))
+
+	var decoded struct {
+		Price Money `json:"price"`
+		Was   Money `json:"was"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"price": 0.30000000000000004, "was": "12.5"}`), &decoded))
+	assert.Equal(t, Money(30), decoded.Price)
+	assert.Equal(t, Money(1250), decoded.Was)
+}
+
+func TestMoney_BSONReadsLegacyDoubles(t *testing.T) {
+	legacy, err := bson.Marshal(bson.M{"price": 19.99, "total": int32(5)})
+	require.NoError(t, err)
+
+	var decoded struct {
+		Price Money `bson:"price"`
+		Total Money `bson:"total"`
+	}
+	require.NoError(t, bson.Unmarshal(legacy, &decoded))
+	assert.Equal(t, Money(1999), decoded.Price)
+	assert.Equal(t, Money(500), decoded.Total)
+
+	stored, err := bson.Marshal(decoded)
+	require.NoError(t, err)
+	var roundTrip struct {
+		Price Money `bson:"price"`
+	}
+	require.NoError(t, bson.Unmarshal(stored, &roundTrip))
+	assert.Equal(t, Money(1999), roundTrip.Price)
+	assert.Equal(t, "19.99", bson.Raw(stored).Lookup("price").Decimal128().String())
+}
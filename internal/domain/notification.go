@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationType string
+
+const (
+	NotificationPriceDrop   NotificationType = "price_drop"
+	NotificationBackInStock NotificationType = "back_in_stock"
+)
+
+type Notification struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Type      NotificationType    `bson:"type" json:"type"`
+	ProductID primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	VariantID *primitive.ObjectID `bson:"variant_id,omitempty" json:"variant_id,omitempty"`
+	Message   string              `bson:"message" json:"message"`
+	ReadAt    *time.Time          `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+}
+
+type NotificationRepository interface {
+	CreateMany(ctx context.Context, notifications []Notification) error
+	FindByUserID(ctx context.Context, userID primitive.ObjectID, query ListQuery) ([]Notification, *Pagination, error)
+	MarkRead(ctx context.Context, userID, id primitive.ObjectID) error
+}
+
+type NotificationUseCase interface {
+	GetByUserID(ctx context.Context, userID string, query ListQuery) ([]Notification, *Pagination, error)
+	MarkRead(ctx context.Context, userID, id string) error
+}
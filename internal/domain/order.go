@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OrderStatus string
+
+const (
+	OrderPlaced OrderStatus = "placed"
+)
+
+type OrderItem struct {
+	ProductID   primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	VariantID   *primitive.ObjectID `bson:"variant_id,omitempty" json:"variant_id,omitempty"`
+	ProductName string              `bson:"product_name" json:"product_name"`
+	VariantSKU  string              `bson:"variant_sku,omitempty" json:"variant_sku,omitempty"`
+	CategoryID  primitive.ObjectID  `bson:"category_id,omitempty" json:"category_id,omitempty"`
+	Category    string              `bson:"category" json:"category"`
+	Quantity    int                 `bson:"quantity" json:"quantity"`
+	UnitPrice   Money               `bson:"unit_price" json:"unit_price"`
+	TotalPrice  Money               `bson:"total_price" json:"total_price"`
+	OnSale      bool                `bson:"on_sale,omitempty" json:"on_sale"`
+	TaxClass    TaxClass            `bson:"tax_class,omitempty" json:"tax_class,omitempty"`
+}
+
+// Order is a checked-out cart. Discounts records what each campaign actually
+// granted, after budget and redemption caps, and Tax is charged on what is
+// left.
+type Order struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	CartID    primitive.ObjectID `bson:"cart_id" json:"cart_id"`
+	Items     []OrderItem        `bson:"items" json:"items"`
+	Currency  Currency           `bson:"currency,omitempty" json:"currency"`
+	Subtotal  Money              `bson:"subtotal" json:"subtotal"`
+	Discount  Money              `bson:"discount" json:"discount"`
+	Tax       *TaxBreakdown      `bson:"tax,omitempty" json:"tax,omitempty"`
+	Total     Money              `bson:"total" json:"total"`
+	Discounts []DiscountLine     `bson:"discounts" json:"discounts"`
+	Status    OrderStatus        `bson:"status" json:"status"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *Order) error
+	FindByID(ctx context.Context, userID, id primitive.ObjectID) (*Order, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID, query ListQuery) ([]Order, *Pagination, error)
+	CountByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+	// HasPurchased reports whether any of the user's orders contains the product.
+	HasPurchased(ctx context.Context, userID, productID primitive.ObjectID) (bool, error)
+	// EachBetween streams the orders placed within [from, to], oldest first.
+	EachBetween(ctx context.Context, from, to time.Time, fn func(*Order) error) error
+	// CampaignStats aggregates the orders in range into stats for one
+	// campaign. Campaign name and range are left for the caller to fill in.
+	CampaignStats(ctx context.Context, campaignID primitive.ObjectID, query CampaignStatsQuery) (*CampaignStats, error)
+}
+
+type OrderUseCase interface {
+	Checkout(ctx context.Context, actor Actor, cartID string) (*Order, error)
+	GetByID(ctx context.Context, userID, id string) (*Order, error)
+	GetByUserID(ctx context.Context, userID string, query ListQuery) ([]Order, *Pagination, error)
+}
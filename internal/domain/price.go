@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReferencePriceWindow is how far back the "was" price looks when a product
+// is reduced: the lowest price charged in that window.
+const ReferencePriceWindow = 30 * 24 * time.Hour
+
+type PriceChangeStatus string
+
+const (
+	PriceChangeScheduled PriceChangeStatus = "scheduled"
+	PriceChangeApplied   PriceChangeStatus = "applied"
+	PriceChangeCancelled PriceChangeStatus = "cancelled"
+)
+
+type PriceChange struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProductID     primitive.ObjectID `bson:"product_id" json:"product_id"`
+	Price         Money              `bson:"price" json:"price"`
+	PreviousPrice Money              `bson:"previous_price" json:"previous_price"`
+	EffectiveFrom time.Time          `bson:"effective_from" json:"effective_from"`
+	Status        PriceChangeStatus  `bson:"status" json:"status"`
+	Reason        string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	ActorID       string             `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	ActorEmail    string             `bson:"actor_email,omitempty" json:"actor_email,omitempty"`
+	AppliedAt     *time.Time         `bson:"applied_at,omitempty" json:"applied_at,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+type PriceSchedule struct {
+	Price         Money      `json:"price" validate:"required,gt=0"`
+	EffectiveFrom *time.Time `json:"effective_from"`
+	Reason        string     `json:"reason" validate:"max=500"`
+}
+
+// ReferencePrice returns the lowest price in force during the window before
+// at, given the applied changes and the price in force immediately before at.
+func ReferencePrice(applied []PriceChange, priceBefore Money, at time.Time) Money {
+	lowest := priceBefore
+	since := at.Add(-ReferencePriceWindow)
+	for _, change := range applied {
+		if change.AppliedAt == nil || !change.AppliedAt.After(since) || !change.AppliedAt.Before(at) {
+			continue
+		}
+		if change.PreviousPrice > 0 && change.PreviousPrice < lowest {
+			lowest = change.PreviousPrice
+		}
+		if change.Price < lowest {
+			lowest = change.Price
+		}
+	}
+	return lowest
+}
+
+type PriceChanged struct {
+	ProductID     primitive.ObjectID `json:"product_id"`
+	ProductName   string             `json:"product_name"`
+	Price         Money              `json:"price"`
+	PreviousPrice Money              `json:"previous_price"`
+}
+
+type PriceChangeRepository interface {
+	Create(ctx context.Context, change *PriceChange) error
+	FindByID(ctx context.Context, id string) (*PriceChange, error)
+	FindByProductID(ctx context.Context, productID primitive.ObjectID, query ListQuery) ([]PriceChange, *Pagination, error)
+	FindAppliedSince(ctx context.Context, productID primitive.ObjectID, since time.Time) ([]PriceChange, error)
+	FindDue(ctx context.Context, now time.Time, limit int) ([]PriceChange, error)
+	// MarkStatus moves a change out of the scheduled state. It reports false
+	// when another caller got there first.
+	MarkStatus(ctx context.Context, id primitive.ObjectID, status PriceChangeStatus, at time.Time) (bool, error)
+	UpdatePreviousPrice(ctx context.Context, id primitive.ObjectID, previous Money) error
+}
+
+type PricingUseCase interface {
+	Schedule(ctx context.Context, productID string, actor Actor, schedule PriceSchedule) (*PriceChange, error)
+	GetHistory(ctx context.Context, productID string, query ListQuery) ([]PriceChange, *Pagination, error)
+	Cancel(ctx context.Context, productID, changeID string) error
+	ApplyDue(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appliedChange(previous, price Money, at time.Time) PriceChange {
+	return PriceChange{PreviousPrice: previous, Price: price, Status: PriceChangeApplied, AppliedAt: &at}
+}
+
+func TestReferencePrice_NoRecentChanges(t *testing.T) {
+	now := time.Now()
+	history := []PriceChange{appliedChange(120_00, 100_00, now.Add(-60*24*time.Hour))}
+
+	assert.Equal(t, Money(100_00), ReferencePrice(history, 100_00, now))
+}
+
+func TestReferencePrice_UsesLowestPriceInWindow(t *testing.T) {
+	now := time.Now()
+	history := []PriceChange{
+		appliedChange(100_00, 80_00, now.Add(-20*24*time.Hour)),
+		appliedChange(80_00, 120_00, now.Add(-10*24*time.Hour)),
+	}
+
+	assert.Equal(t, Money(80_00), ReferencePrice(history, 120_00, now))
+}
+
+func TestReferencePrice_IgnoresChangesOutsideWindow(t *testing.T) {
+	now := time.Now()
+	history := []PriceChange{
+		appliedChange(100_00, 50_00, now.Add(-45*24*time.Hour)),
+		appliedChange(50_00, 110_00, now.Add(-40*24*time.Hour)),
+	}
+
+	assert.Equal(t, Money(110_00), ReferencePrice(history, 110_00, now))
+}
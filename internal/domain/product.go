@@ -8,30 +8,153 @@ import (
 )
 
 type Product struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Name        string             `bson:"name" json:"name" validate:"required"`
-	Description string             `bson:"description" json:"description" validate:"required"`
-	Content     string             `bson:"content" json:"content" validate:"required"`
-	Price       float64            `bson:"price" json:"price" validate:"required"`
-	Image       string             `bson:"image" json:"image" validate:"required"`
-	Sold        int                `bson:"sold" json:"sold"`
-	Stock       int                `bson:"stock" json:"stock"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	SKU           string             `bson:"sku,omitempty" json:"sku,omitempty"`
+	Slug          string             `bson:"slug,omitempty" json:"slug"`
+	PreviousSlugs []string           `bson:"previous_slugs,omitempty" json:"-"`
+	Name          string             `bson:"name" json:"name" validate:"required"`
+	Description   string             `bson:"description" json:"description" validate:"required"`
+	Content       string             `bson:"content" json:"content" validate:"required"`
+	Price         Money              `bson:"price" json:"price" validate:"required"`
+	WasPrice      *Money             `bson:"was_price,omitempty" json:"was_price,omitempty"`
+	// Prices lists the product's price in other currencies. Currencies
+	// without an entry are converted from Price at the exchange rate.
+	Prices            map[Currency]Money `bson:"prices,omitempty" json:"prices,omitempty"`
+	TaxClass          TaxClass           `bson:"tax_class,omitempty" json:"tax_class,omitempty"`
+	Image             string             `bson:"image" json:"image" validate:"required"`
+	Images            []ProductImage     `bson:"images,omitempty" json:"images,omitempty"`
+	CategoryID        primitive.ObjectID `bson:"category_id,omitempty" json:"category_id,omitempty"`
+	Variants          []ProductVariant   `bson:"variants,omitempty" json:"variants,omitempty"`
+	Sold              int                `bson:"sold" json:"sold"`
+	RatingAverage     float64            `bson:"rating_average" json:"rating_average"`
+	RatingCount       int                `bson:"rating_count" json:"rating_count"`
+	RatingSum         int                `bson:"rating_sum" json:"-"`
+	Stock             int                `bson:"stock" json:"stock"`
+	LowStockThreshold *int               `bson:"low_stock_threshold,omitempty" json:"low_stock_threshold,omitempty"`
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+	DeletedAt         *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+}
+
+type ProductVariant struct {
+	ID      primitive.ObjectID `bson:"_id" json:"id"`
+	SKU     string             `bson:"sku" json:"sku" validate:"required"`
+	Options map[string]string  `bson:"options" json:"options"`
+	Price   *Money             `bson:"price,omitempty" json:"price,omitempty"`
+	Stock   int                `bson:"stock" json:"stock"`
+	Image   string             `bson:"image,omitempty" json:"image,omitempty"`
+}
+
+type ProductImage struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	URL          string             `bson:"url" json:"url"`
+	ThumbnailURL string             `bson:"thumbnail_url" json:"thumbnail_url"`
+	Key          string             `bson:"key" json:"-"`
+	ThumbnailKey string             `bson:"thumbnail_key" json:"-"`
+	ContentType  string             `bson:"content_type" json:"content_type"`
+	Size         int64              `bson:"size" json:"size"`
+	Width        int                `bson:"width" json:"width"`
+	Height       int                `bson:"height" json:"height"`
+	Position     int                `bson:"position" json:"position"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+func (p *Product) Variant(id primitive.ObjectID) *ProductVariant {
+	for i := range p.Variants {
+		if p.Variants[i].ID == id {
+			return &p.Variants[i]
+		}
+	}
+	return nil
+}
+
+func (p *Product) PriceFor(variant *ProductVariant) Money {
+	if variant != nil && variant.Price != nil {
+		return *variant.Price
+	}
+	return p.Price
+}
+
+// ListPrice returns the price list entry for currency. It only applies to
+// variants without a price of their own.
+func (p *Product) ListPrice(currency Currency, variant *ProductVariant) (Money, bool) {
+	if variant != nil && variant.Price != nil {
+		return 0, false
+	}
+	price, ok := p.Prices[currency]
+	return price, ok
+}
+
+func (p *Product) ValidatePrices() error {
+	for currency, price := range p.Prices {
+		if !currency.Valid() || currency == BaseCurrency || price <= 0 {
+			return ErrInvalidPriceList
+		}
+	}
+	return nil
+}
+
+// LowStockLevel returns the product's own threshold, falling back to the
+// store-wide default when none is configured.
+func (p *Product) LowStockLevel(fallback int) int {
+	if p.LowStockThreshold != nil {
+		return *p.LowStockThreshold
+	}
+	return fallback
+}
+
+func (p *Product) StockFor(variant *ProductVariant) int {
+	if variant != nil {
+		return variant.Stock
+	}
+	return p.Stock
 }
 
 type ProductRepository interface {
 	Create(ctx context.Context, product *Product) error
 	FindByID(ctx context.Context, id string) (*Product, error)
-	FindAll(ctx context.Context) ([]Product, error)
+	FindBySKU(ctx context.Context, sku string) (*Product, error)
+	FindByName(ctx context.Context, name string) (*Product, error)
+	FindBySlug(ctx context.Context, slug string) (*Product, error)
+	SlugTaken(ctx context.Context, slug string, exclude primitive.ObjectID) (bool, error)
+	FindByVariantSKU(ctx context.Context, sku string) (*Product, error)
+	FindAll(ctx context.Context, query ListQuery) ([]Product, *Pagination, error)
 	Update(ctx context.Context, product *Product) error
+	UpdateVariants(ctx context.Context, id primitive.ObjectID, variants []ProductVariant) error
+	UpdateImages(ctx context.Context, id primitive.ObjectID, images []ProductImage) error
+	AdjustRating(ctx context.Context, id primitive.ObjectID, countDelta, sumDelta int) error
+	UpdatePrice(ctx context.Context, id primitive.ObjectID, price Money, wasPrice *Money) error
+	AdjustStock(ctx context.Context, id primitive.ObjectID, variantID *primitive.ObjectID, delta int) (*Product, error)
+	// SetStock moves stock from observed to level, failing with
+	// ErrStockChanged if it no longer stands at observed.
+	SetStock(ctx context.Context, id primitive.ObjectID, variantID *primitive.ObjectID, observed, level int) (*Product, error)
+	FindLowStock(ctx context.Context, defaultThreshold int, query ListQuery) ([]Product, *Pagination, error)
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	FindArchived(ctx context.Context, query ListQuery) ([]Product, *Pagination, error)
+	ArchivePurger
+	Each(ctx context.Context, fn func(*Product) error) error
 }
 
 type ProductUseCase interface {
 	Create(ctx context.Context, product *Product) error
 	GetByID(ctx context.Context, id string) (*Product, error)
-	GetAll(ctx context.Context) ([]Product, error)
+	GetBySlug(ctx context.Context, slug string) (*Product, error)
+	GetAll(ctx context.Context, query ListQuery) ([]Product, *Pagination, error)
+	EnsureSlugs(ctx context.Context) (int, error)
+	Search(ctx context.Context, query ProductSearchQuery) (*ProductSearchResult, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]string, error)
 	Update(ctx context.Context, product *Product) error
+	AddVariant(ctx context.Context, productID string, variant *ProductVariant) error
+	UpdateVariant(ctx context.Context, productID string, variant *ProductVariant) error
+	DeleteVariant(ctx context.Context, productID, variantID string) error
 	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*Product, error)
+	GetArchived(ctx context.Context, query ListQuery) ([]Product, *Pagination, error)
+}
+
+type ProductImageUseCase interface {
+	Upload(ctx context.Context, productID string, data []byte) (*ProductImage, error)
+	Reorder(ctx context.Context, productID string, imageIDs []string) ([]ProductImage, error)
+	Delete(ctx context.Context, productID, imageID string) error
 }
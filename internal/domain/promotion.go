@@ -0,0 +1,267 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ItemTarget selects cart items by product, by category, or both. Category
+// is a category ID, or a name for rules saved before IDs were accepted, and
+// takes in its subcategories.
+type ItemTarget struct {
+	ProductIDs []primitive.ObjectID `json:"product_ids,omitempty"`
+	Category   string               `json:"category,omitempty"`
+}
+
+func (t ItemTarget) Empty() bool {
+	return len(t.ProductIDs) == 0 && t.Category == ""
+}
+
+func (t ItemTarget) key() string {
+	if t.Category != "" {
+		return "category:" + strings.ToLower(t.Category)
+	}
+	return "products:" + joinObjectIDs(t.ProductIDs)
+}
+
+// BuyXGetY discounts GetQuantity of the cheapest Get items for every
+// BuyQuantity Buy items in the cart. Percentage is taken off the Get items,
+// so 100 makes them free.
+type BuyXGetY struct {
+	Buy         ItemTarget `json:"buy"`
+	BuyQuantity int        `json:"buy_quantity"`
+	Get         ItemTarget `json:"get"`
+	GetQuantity int        `json:"get_quantity"`
+	Percentage  float64    `json:"percentage"`
+}
+
+func (o BuyXGetY) Valid() bool {
+	return o.BuyQuantity > 0 && o.GetQuantity > 0 && !o.Buy.Empty() && !o.Get.Empty() &&
+		o.Percentage > 0 && o.Percentage <= 100
+}
+
+// Bundle sells one unit of each listed product for Price. A product listed
+// twice needs two units.
+type Bundle struct {
+	ProductIDs []primitive.ObjectID `json:"product_ids"`
+	Price      Money                `json:"price"`
+}
+
+func (b Bundle) Valid() bool {
+	return len(b.ProductIDs) > 0 && b.Price > 0
+}
+
+// DiscountAllocation is the share of a discount carried by one cart line.
+type DiscountAllocation struct {
+	ProductID primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	VariantID *primitive.ObjectID `bson:"variant_id,omitempty" json:"variant_id,omitempty"`
+	Quantity  int                 `bson:"quantity" json:"quantity"`
+	Amount    Money               `bson:"amount" json:"amount"`
+}
+
+func SumAllocations(allocations []DiscountAllocation) Money {
+	var total Money
+	for _, allocation := range allocations {
+		total += allocation.Amount
+	}
+	return total
+}
+
+// ScaleAllocations spreads amount over the allocations in proportion to what
+// each carried, so they still add up exactly.
+func ScaleAllocations(allocations []DiscountAllocation, amount Money) []DiscountAllocation {
+	weights := make([]Money, len(allocations))
+	for i, allocation := range allocations {
+		weights[i] = allocation.Amount
+	}
+	scaled := make([]DiscountAllocation, len(allocations))
+	for i, part := range Allocate(amount, weights) {
+		scaled[i] = allocations[i]
+		scaled[i].Amount = part
+	}
+	return scaled
+}
+
+// BuyXGetY reads the rule as a buy X get Y offer. BOGO is buy one get one
+// free, and an empty get target means the same items as the buy target.
+func (r *DiscountRule) BuyXGetY() BuyXGetY {
+	offer := BuyXGetY{
+		Buy:         ItemTarget{ProductIDs: r.BuyProductIDs, Category: r.BuyCategory},
+		BuyQuantity: r.BuyQuantity,
+		Get:         ItemTarget{ProductIDs: r.GetProductIDs, Category: r.GetCategory},
+		GetQuantity: r.GetQuantity,
+		Percentage:  r.Percentage,
+	}
+	if r.DiscuntType == DiscountTypeBOGO {
+		offer.BuyQuantity, offer.GetQuantity = 1, 1
+	}
+	if offer.Get.Empty() {
+		offer.Get = offer.Buy
+	}
+	if offer.Percentage == 0 {
+		offer.Percentage = 100
+	}
+	return offer
+}
+
+func (r *DiscountRule) Bundle() Bundle {
+	return Bundle{ProductIDs: r.BundleProductIDs, Price: r.BundlePrice}
+}
+
+type itemUnit struct {
+	line  int
+	index int
+	price Money
+}
+
+func itemUnits(items []CartItem, match func(CartItem) bool) []itemUnit {
+	var units []itemUnit
+	for line, item := range items {
+		if !match(item) {
+			continue
+		}
+		for i := 0; i < item.Quantity; i++ {
+			units = append(units, itemUnit{line: line, index: i, price: item.UnitPrice})
+		}
+	}
+	return units
+}
+
+// AllocateBuyXGetY pairs the most expensive buy units with the cheapest get
+// units, so the customer always gets the cheapest items discounted. A unit
+// is only ever counted once, either as bought or as rewarded.
+func AllocateBuyXGetY(items []CartItem, offer BuyXGetY, rounding Rounding, isBuy, isGet func(CartItem) bool) []DiscountAllocation {
+	buys := itemUnits(items, isBuy)
+	sort.SliceStable(buys, func(i, j int) bool { return buys[i].price > buys[j].price })
+	gets := itemUnits(items, isGet)
+	sort.SliceStable(gets, func(i, j int) bool { return gets[i].price < gets[j].price })
+
+	used := map[[2]int]bool{}
+	take := func(units []itemUnit, next *int, n int) []itemUnit {
+		var taken []itemUnit
+		for *next < len(units) && len(taken) < n {
+			unit := units[*next]
+			*next++
+			if !used[[2]int{unit.line, unit.index}] {
+				taken = append(taken, unit)
+			}
+		}
+		return taken
+	}
+
+	rewardedPrices := make([]Money, len(items))
+	quantities := make([]int, len(items))
+	var nextBuy, nextGet int
+	for {
+		bought := take(buys, &nextBuy, offer.BuyQuantity)
+		if len(bought) < offer.BuyQuantity {
+			break
+		}
+		for _, unit := range bought {
+			used[[2]int{unit.line, unit.index}] = true
+		}
+		rewarded := take(gets, &nextGet, offer.GetQuantity)
+		if len(rewarded) < offer.GetQuantity {
+			break
+		}
+		for _, unit := range rewarded {
+			used[[2]int{unit.line, unit.index}] = true
+			rewardedPrices[unit.line] += unit.price
+			quantities[unit.line]++
+		}
+	}
+	return allocations(items, rounding.Percent(rewardedPrices, offer.Percentage), quantities)
+}
+
+// AllocateBundle builds as many bundles as the cart holds, most expensive
+// units first, and spreads each bundle's saving over its units by price.
+// Bundles that would cost more than buying the items alone are skipped.
+func AllocateBundle(items []CartItem, bundle Bundle) []DiscountAllocation {
+	var ids []primitive.ObjectID
+	required := map[primitive.ObjectID]int{}
+	for _, id := range bundle.ProductIDs {
+		if required[id] == 0 {
+			ids = append(ids, id)
+		}
+		required[id]++
+	}
+
+	available := map[primitive.ObjectID][]itemUnit{}
+	for id := range required {
+		id := id
+		units := itemUnits(items, func(item CartItem) bool { return item.ProductId == id })
+		sort.SliceStable(units, func(i, j int) bool { return units[i].price > units[j].price })
+		available[id] = units
+	}
+
+	count := math.MaxInt
+	for id, n := range required {
+		if sets := len(available[id]) / n; sets < count {
+			count = sets
+		}
+	}
+
+	discounts := make([]Money, len(items))
+	quantities := make([]int, len(items))
+	for k := 0; k < count; k++ {
+		var members []itemUnit
+		var prices []Money
+		var full Money
+		for _, id := range ids {
+			n := required[id]
+			for _, unit := range available[id][k*n : (k+1)*n] {
+				members = append(members, unit)
+				prices = append(prices, unit.price)
+				full += unit.price
+			}
+		}
+
+		saving := full - bundle.Price
+		if saving <= 0 {
+			continue
+		}
+		for i, share := range Allocate(saving, prices) {
+			discounts[members[i].line] += share
+			quantities[members[i].line]++
+		}
+	}
+	return allocations(items, discounts, quantities)
+}
+
+// LineAllocations allocates discounts, one per cart line, to the whole of
+// each line.
+func LineAllocations(items []CartItem, discounts []Money) []DiscountAllocation {
+	quantities := make([]int, len(items))
+	for i, item := range items {
+		quantities[i] = item.Quantity
+	}
+	return allocations(items, discounts, quantities)
+}
+
+func allocations(items []CartItem, discounts []Money, quantities []int) []DiscountAllocation {
+	var result []DiscountAllocation
+	for line, amount := range discounts {
+		if amount <= 0 {
+			continue
+		}
+		result = append(result, DiscountAllocation{
+			ProductID: items[line].ProductId,
+			VariantID: items[line].VariantId,
+			Quantity:  quantities[line],
+			Amount:    amount,
+		})
+	}
+	return result
+}
+
+func joinObjectIDs(ids []primitive.ObjectID) string {
+	hexes := make([]string, 0, len(ids))
+	for _, id := range ids {
+		hexes = append(hexes, id.Hex())
+	}
+	sort.Strings(hexes)
+	return strings.Join(hexes, ",")
+}
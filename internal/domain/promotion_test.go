@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func cartLine(productID primitive.ObjectID, category string, quantity int, unitPrice Money) CartItem {
+	return CartItem{
+		ProductId:  productID,
+		Category:   category,
+		Quantity:   quantity,
+		UnitPrice:  unitPrice,
+		TotalPrice: unitPrice.Times(quantity),
+	}
+}
+
+var perOrder = Rounding{Mode: RoundHalfEven, Scope: RoundPerOrder}
+
+func inCategory(category string) func(CartItem) bool {
+	return func(item CartItem) bool { return item.Category == category }
+}
+
+func TestAllocateBuyXGetY_BOGOFreesCheapestUnits(t *testing.T) {
+	shirt, socks := primitive.NewObjectID(), primitive.NewObjectID()
+	items := []CartItem{
+		cartLine(shirt, "clothing", 2, 10_00),
+		cartLine(socks, "clothing", 2, 4_00),
+	}
+	rule := DiscountRule{DiscuntType: DiscountTypeBOGO, BuyCategory: "clothing"}
+
+	allocations := AllocateBuyXGetY(items, rule.BuyXGetY(), perOrder, inCategory("clothing"), inCategory("clothing"))
+
+	assert.Equal(t, []DiscountAllocation{{ProductID: socks, Quantity: 2, Amount: 8_00}}, allocations)
+}
+
+func TestAllocateBuyXGetY_DifferentRewardCategory(t *testing.T) {
+	phone, cover := primitive.NewObjectID(), primitive.NewObjectID()
+	items := []CartItem{
+		cartLine(phone, "phones", 3, 300_00),
+		cartLine(cover, "accessories", 2, 20_00),
+	}
+	offer := BuyXGetY{
+		Buy:         ItemTarget{Category: "phones"},
+		BuyQuantity: 2,
+		Get:         ItemTarget{Category: "accessories"},
+		GetQuantity: 1,
+		Percentage:  50,
+	}
+
+	allocations := AllocateBuyXGetY(items, offer, perOrder, inCategory("phones"), inCategory("accessories"))
+
+	assert.Equal(t, []DiscountAllocation{{ProductID: cover, Quantity: 1, Amount: 10_00}}, allocations)
+}
+
+func TestAllocateBundle_SpreadsSavingByPrice(t *testing.T) {
+	a, b := primitive.NewObjectID(), primitive.NewObjectID()
+	items := []CartItem{
+		cartLine(a, "games", 1, 600_00),
+		cartLine(b, "games", 1, 400_00),
+	}
+
+	allocations := AllocateBundle(items, Bundle{ProductIDs: []primitive.ObjectID{a, b}, Price: 900_00})
+
+	assert.Len(t, allocations, 2)
+	assert.Equal(t, Money(60_00), allocations[0].Amount)
+	assert.Equal(t, Money(40_00), allocations[1].Amount)
+}
+
+func TestAllocateBundle_IncompleteBundleGetsNothing(t *testing.T) {
+	a, b := primitive.NewObjectID(), primitive.NewObjectID()
+	items := []CartItem{cartLine(a, "games", 2, 600_00)}
+
+	assert.Empty(t, AllocateBundle(items, Bundle{ProductIDs: []primitive.ObjectID{a, b}, Price: 900_00}))
+}
+
+func TestAllocateBundle_SavingAddsUpExactly(t *testing.T) {
+	a, b, c := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	items := []CartItem{
+		cartLine(a, "games", 1, 100_00),
+		cartLine(b, "games", 1, 100_00),
+		cartLine(c, "games", 1, 100_00),
+	}
+
+	allocations := AllocateBundle(items, Bundle{ProductIDs: []primitive.ObjectID{a, b, c}, Price: 200_00})
+
+	assert.Equal(t, Money(100_00), SumAllocations(allocations))
+	assert.Equal(t, Money(33_34), allocations[0].Amount)
+}
+
+func TestAllocateBundle_SplitsSavingInBundleOrder(t *testing.T) {
+	a, b, c := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	items := []CartItem{
+		cartLine(a, "games", 1, 100_00),
+		cartLine(b, "games", 1, 100_00),
+		cartLine(c, "games", 1, 100_00),
+	}
+	bundle := Bundle{ProductIDs: []primitive.ObjectID{b, c, a}, Price: 200_00}
+
+	// The satang left over goes to the first product of the bundle, not of
+	// the cart.
+	want := []DiscountAllocation{
+		{ProductID: a, Quantity: 1, Amount: 33_33},
+		{ProductID: b, Quantity: 1, Amount: 33_34},
+		{ProductID: c, Quantity: 1, Amount: 33_33},
+	}
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, want, AllocateBundle(items, bundle))
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecommendationSettings tune the co-occurrence rebuild. Pairs seen in fewer
+// than MinSupport baskets are treated as noise.
+type RecommendationSettings struct {
+	MinSupport  int
+	PerProduct  int
+	MaxCartSize int
+}
+
+type RecommendationRepository interface {
+	// Rebuild recomputes "bought together" pairs from cart and order contents
+	// and replaces the stored table, returning the number of pairs kept.
+	Rebuild(ctx context.Context, settings RecommendationSettings) (int64, error)
+	// FindRelated ranks products that co-occur with any of productIDs,
+	// skipping productIDs themselves, archived and out of stock products.
+	FindRelated(ctx context.Context, productIDs []primitive.ObjectID, limit int) ([]Product, error)
+}
+
+type RecommendationUseCase interface {
+	ForProduct(ctx context.Context, productID string, limit int) ([]Product, error)
+	ForCart(ctx context.Context, userID string, isAdmin bool, cartID string, limit int) ([]Product, error)
+	Refresh(ctx context.Context) (int64, error)
+}
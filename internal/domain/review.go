@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published"
+	ReviewStatusHidden    ReviewStatus = "hidden"
+)
+
+func (s ReviewStatus) Valid() bool {
+	return s == ReviewStatusPublished || s == ReviewStatusHidden
+}
+
+type Review struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ProductID      primitive.ObjectID `bson:"product_id" json:"product_id"`
+	UserID         primitive.ObjectID `bson:"user_id" json:"user_id"`
+	UserName       string             `bson:"user_name" json:"user_name"`
+	Rating         int                `bson:"rating" json:"rating" validate:"required,min=1,max=5"`
+	Title          string             `bson:"title,omitempty" json:"title,omitempty" validate:"max=120"`
+	Body           string             `bson:"body" json:"body" validate:"required,max=5000"`
+	Status         ReviewStatus       `bson:"status" json:"status"`
+	ModerationNote string             `bson:"moderation_note,omitempty" json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time         `bson:"moderated_at,omitempty" json:"moderated_at,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+func (r *Review) Published() bool {
+	return r.Status == ReviewStatusPublished
+}
+
+type ReviewModeration struct {
+	Status ReviewStatus `json:"status" validate:"required"`
+	Note   string       `json:"note" validate:"max=500"`
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *Review) error
+	FindByID(ctx context.Context, id string) (*Review, error)
+	FindByProductID(ctx context.Context, productID primitive.ObjectID, query ListQuery) ([]Review, *Pagination, error)
+	FindAll(ctx context.Context, query ListQuery) ([]Review, *Pagination, error)
+	Update(ctx context.Context, review *Review) error
+	Delete(ctx context.Context, id string) error
+}
+
+type ReviewUseCase interface {
+	Create(ctx context.Context, userID string, review *Review) error
+	GetByProductID(ctx context.Context, productID string, query ListQuery) ([]Review, *Pagination, error)
+	GetAll(ctx context.Context, query ListQuery) ([]Review, *Pagination, error)
+	Update(ctx context.Context, userID string, review *Review) error
+	Moderate(ctx context.Context, id string, moderation ReviewModeration) (*Review, error)
+	Delete(ctx context.Context, userID string, isAdmin bool, id string) error
+}
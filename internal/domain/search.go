@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var SearchPriceBoundaries = []Money{0, 100_00, 500_00, 1000_00, 5000_00}
+
+type ProductSearchQuery struct {
+	Query       string
+	CategoryID  string
+	CategoryIDs []primitive.ObjectID
+	MinPrice    *Money
+	MaxPrice    *Money
+	Limit       int
+	Offset      int
+}
+
+type ProductSearchHit struct {
+	Product `bson:",inline"`
+	Score   float64 `bson:"score" json:"score"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+type PriceBucketCount struct {
+	Min   Money  `json:"min"`
+	Max   *Money `json:"max,omitempty"`
+	Count int64  `json:"count"`
+}
+
+type SearchFacets struct {
+	Categories   []FacetCount       `json:"categories"`
+	PriceBuckets []PriceBucketCount `json:"price_buckets"`
+}
+
+type ProductSearchResult struct {
+	Hits   []ProductSearchHit `json:"hits"`
+	Total  int64              `json:"total"`
+	Fuzzy  bool               `json:"fuzzy"`
+	Facets SearchFacets       `json:"facets"`
+}
+
+type SearchIndex interface {
+	Index(ctx context.Context, product *Product) error
+	Remove(ctx context.Context, id string) error
+	Search(ctx context.Context, query ProductSearchQuery) (*ProductSearchResult, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]string, error)
+}
+
+// PriceBucketIndex returns the position in SearchPriceBoundaries of the bucket containing price.
+func PriceBucketIndex(price Money) int {
+	index := 0
+	for i, boundary := range SearchPriceBoundaries {
+		if price >= boundary {
+			index = i
+		}
+	}
+	return index
+}
+
+func NewPriceBucketCounts() []PriceBucketCount {
+	buckets := make([]PriceBucketCount, len(SearchPriceBoundaries))
+	for i, boundary := range SearchPriceBoundaries {
+		buckets[i].Min = boundary
+		if i+1 < len(SearchPriceBoundaries) {
+			max := SearchPriceBoundaries[i+1]
+			buckets[i].Max = &max
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SegmentRuleType string
+
+const (
+	SegmentRuleNewCustomer      SegmentRuleType = "new_customer"
+	SegmentRuleOrdersMoreThan   SegmentRuleType = "orders_more_than"
+	SegmentRuleRole             SegmentRuleType = "role"
+	SegmentRuleRegisteredAfter  SegmentRuleType = "registered_after"
+	SegmentRuleRegisteredBefore SegmentRuleType = "registered_before"
+	SegmentRulePointsAtLeast    SegmentRuleType = "points_at_least"
+)
+
+// SegmentRule is one condition on a customer. Only the field that belongs to
+// the rule's type is read.
+type SegmentRule struct {
+	Type   SegmentRuleType `bson:"type" json:"type"`
+	Orders int             `bson:"orders,omitempty" json:"orders,omitempty"`
+	Role   string          `bson:"role,omitempty" json:"role,omitempty"`
+	Date   *time.Time      `bson:"date,omitempty" json:"date,omitempty"`
+	Points int             `bson:"points,omitempty" json:"points,omitempty"`
+}
+
+func (r SegmentRule) Valid() bool {
+	switch r.Type {
+	case SegmentRuleNewCustomer:
+		return true
+	case SegmentRuleOrdersMoreThan:
+		return r.Orders >= 0
+	case SegmentRuleRole:
+		return r.Role != ""
+	case SegmentRuleRegisteredAfter, SegmentRuleRegisteredBefore:
+		return r.Date != nil
+	case SegmentRulePointsAtLeast:
+		return r.Points >= 0
+	}
+	return false
+}
+
+func (r SegmentRule) Matches(p *CustomerProfile) bool {
+	switch r.Type {
+	case SegmentRuleNewCustomer:
+		return p.Orders == 0
+	case SegmentRuleOrdersMoreThan:
+		return p.Orders > int64(r.Orders)
+	case SegmentRuleRole:
+		return strings.EqualFold(p.Role, r.Role)
+	case SegmentRuleRegisteredAfter:
+		return p.RegisteredAt.After(*r.Date)
+	case SegmentRuleRegisteredBefore:
+		return p.RegisteredAt.Before(*r.Date)
+	case SegmentRulePointsAtLeast:
+		return p.Points >= r.Points
+	}
+	return false
+}
+
+// Segment is a named group of customers; a customer belongs to it when every
+// rule matches.
+type Segment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name" validate:"required,max=120"`
+	Description string             `bson:"description,omitempty" json:"description" validate:"max=500"`
+	Rules       []SegmentRule      `bson:"rules" json:"rules" validate:"required,min=1"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+func (s *Segment) Matches(p *CustomerProfile) bool {
+	for _, rule := range s.Rules {
+		if !rule.Matches(p) {
+			return false
+		}
+	}
+	return len(s.Rules) > 0
+}
+
+// CustomerProfile is the view of a customer that segment rules read.
+type CustomerProfile struct {
+	UserID       primitive.ObjectID `json:"user_id"`
+	Role         string             `json:"role"`
+	RegisteredAt time.Time          `json:"registered_at"`
+	Orders       int64              `json:"orders"`
+	Points       int                `json:"points"`
+}
+
+type SegmentRepository interface {
+	Create(ctx context.Context, segment *Segment) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*Segment, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]Segment, error)
+	FindAll(ctx context.Context, query ListQuery) ([]Segment, *Pagination, error)
+	Update(ctx context.Context, segment *Segment) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type SegmentUseCase interface {
+	Create(ctx context.Context, segment *Segment) error
+	GetByID(ctx context.Context, id string) (*Segment, error)
+	GetAll(ctx context.Context, query ListQuery) ([]Segment, *Pagination, error)
+	Update(ctx context.Context, segment *Segment) error
+	Delete(ctx context.Context, id string) error
+	Profile(ctx context.Context, userID primitive.ObjectID) (*CustomerProfile, error)
+	// Eligible reports whether the customer belongs to any of segmentIDs. An
+	// empty list means the campaign is open to everyone.
+	Eligible(ctx context.Context, profile *CustomerProfile, segmentIDs []primitive.ObjectID) (bool, error)
+}
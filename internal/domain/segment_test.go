@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegment_NewCustomerExcludesReturningCustomers(t *testing.T) {
+	segment := Segment{Rules: []SegmentRule{{Type: SegmentRuleNewCustomer}}}
+
+	assert.True(t, segment.Matches(&CustomerProfile{Orders: 0}))
+	assert.False(t, segment.Matches(&CustomerProfile{Orders: 1}))
+}
+
+func TestSegment_AllRulesMustMatch(t *testing.T) {
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	segment := Segment{Rules: []SegmentRule{
+		{Type: SegmentRuleOrdersMoreThan, Orders: 2},
+		{Type: SegmentRuleRegisteredBefore, Date: &cutoff},
+	}}
+
+	loyal := &CustomerProfile{Orders: 3, RegisteredAt: cutoff.AddDate(-1, 0, 0)}
+	recent := &CustomerProfile{Orders: 3, RegisteredAt: cutoff.AddDate(0, 1, 0)}
+
+	assert.True(t, segment.Matches(loyal))
+	assert.False(t, segment.Matches(recent))
+}
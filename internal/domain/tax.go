@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TaxClass groups products taxed at the same rate. Products without one are
+// standard rated.
+type TaxClass string
+
+const (
+	TaxClassStandard TaxClass = "standard"
+	TaxClassReduced  TaxClass = "reduced"
+	TaxClassZero     TaxClass = "zero"
+	// TaxClassExempt is outside the tax altogether, unlike zero rated
+	// goods, which are taxed at 0%.
+	TaxClassExempt TaxClass = "exempt"
+)
+
+func (c TaxClass) Valid() bool {
+	switch c {
+	case "", TaxClassStandard, TaxClassReduced, TaxClassZero, TaxClassExempt:
+		return true
+	}
+	return false
+}
+
+func (c TaxClass) OrStandard() TaxClass {
+	if c == "" {
+		return TaxClassStandard
+	}
+	return c
+}
+
+// TaxRegion holds the rates one region charges per tax class, as
+// percentages. Inclusive regions quote prices with the tax already in them;
+// exclusive ones add it on top of the discounted total.
+type TaxRegion struct {
+	Code      string               `bson:"_id" json:"code"`
+	Name      string               `bson:"name" json:"name" validate:"required"`
+	Inclusive bool                 `bson:"inclusive" json:"inclusive"`
+	Rates     map[TaxClass]float64 `bson:"rates" json:"rates" validate:"required"`
+	UpdatedBy string               `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// DefaultTaxRegion is Thai VAT, charged at 7% and included in prices. It is
+// used until a region with its code is configured.
+func DefaultTaxRegion() TaxRegion {
+	return TaxRegion{
+		Code:      "TH",
+		Name:      "Thailand VAT",
+		Inclusive: true,
+		Rates: map[TaxClass]float64{
+			TaxClassStandard: 7,
+			TaxClassZero:     0,
+		},
+	}
+}
+
+func (r *TaxRegion) Validate() error {
+	if !ValidTaxRegionCode(r.Code) {
+		return ErrInvalidTaxRegion
+	}
+	for class, rate := range r.Rates {
+		if class == "" || class == TaxClassExempt || !class.Valid() || rate < 0 || rate > 100 {
+			return ErrInvalidTaxRegion
+		}
+	}
+	return nil
+}
+
+// Rate returns the percentage charged on class. Exempt goods are not taxed,
+// and classes the region has no rate for are charged nothing.
+func (r *TaxRegion) Rate(class TaxClass) (float64, bool) {
+	class = class.OrStandard()
+	if class == TaxClassExempt {
+		return 0, false
+	}
+	return r.Rates[class], true
+}
+
+// ValidTaxRegionCode accepts upper case codes such as "TH" or "US-CA".
+func ValidTaxRegionCode(code string) bool {
+	if code == "" || len(code) > 10 {
+		return false
+	}
+	for _, r := range code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// IncludedTax is the tax within m when m already includes percentage,
+// rounded with mode.
+func (m Money) IncludedTax(percentage float64, mode RoundingMode) Money {
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(percentage, 'f', -1, 64))
+	if !ok || rate.Sign() <= 0 {
+		return 0
+	}
+	r := new(big.Rat).Mul(big.NewRat(int64(m), 1), rate)
+	return roundRat(r.Quo(r, rate.Add(rate, big.NewRat(100, 1))), mode)
+}
+
+// TaxableLine is a cart line after its discounts.
+type TaxableLine struct {
+	ProductID primitive.ObjectID
+	VariantID *primitive.ObjectID
+	Class     TaxClass
+	Amount    Money
+}
+
+// TaxableLines takes each discount off the lines it was allocated to. A
+// discount without allocations is spread over the lines by what is left of
+// them. No line goes below zero.
+func TaxableLines(items []CartItem, discounts []DiscountLine) []TaxableLine {
+	lines := make([]TaxableLine, len(items))
+	for i, item := range items {
+		lines[i] = TaxableLine{
+			ProductID: item.ProductId,
+			VariantID: item.VariantId,
+			Class:     item.TaxClass,
+			Amount:    item.TotalPrice,
+		}
+	}
+
+	var unallocated Money
+	for _, discount := range discounts {
+		if len(discount.Items) == 0 {
+			unallocated += discount.Amount
+			continue
+		}
+		for _, allocation := range discount.Items {
+			for i := range lines {
+				if lines[i].ProductID == allocation.ProductID && sameVariant(lines[i].VariantID, allocation.VariantID) {
+					lines[i].Amount = MaxMoney(lines[i].Amount-allocation.Amount, 0)
+					break
+				}
+			}
+		}
+	}
+
+	if unallocated > 0 {
+		weights := make([]Money, len(lines))
+		for i, line := range lines {
+			weights[i] = line.Amount
+		}
+		for i, part := range Allocate(unallocated, weights) {
+			lines[i].Amount = MaxMoney(lines[i].Amount-part, 0)
+		}
+	}
+	return lines
+}
+
+func sameVariant(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// TaxLine is the tax charged on one class, as it appears on an invoice. Base
+// is the amount the tax is charged on, without the tax.
+type TaxLine struct {
+	Class TaxClass `bson:"class" json:"class"`
+	Rate  float64  `bson:"rate" json:"rate"`
+	Base  Money    `bson:"base" json:"base"`
+	Tax   Money    `bson:"tax" json:"tax"`
+}
+
+type TaxBreakdown struct {
+	Region    string    `bson:"region" json:"region"`
+	Inclusive bool      `bson:"inclusive" json:"inclusive"`
+	Lines     []TaxLine `bson:"lines" json:"lines"`
+	Total     Money     `bson:"total" json:"total"`
+}
+
+// Added is the tax to put on top of the discounted total. Inclusive tax is
+// already in the prices.
+func (b *TaxBreakdown) Added() Money {
+	if b == nil || b.Inclusive {
+		return 0
+	}
+	return b.Total
+}
+
+// TaxCalculator works out the tax on lines already net of discounts, so a
+// regime other than VAT can be plugged in.
+type TaxCalculator interface {
+	Calculate(region TaxRegion, lines []TaxableLine) TaxBreakdown
+}
+
+type TaxRegionRepository interface {
+	FindAll(ctx context.Context) ([]TaxRegion, error)
+	FindByCode(ctx context.Context, code string) (*TaxRegion, error)
+	Upsert(ctx context.Context, region *TaxRegion) error
+	Delete(ctx context.Context, code string) error
+}
+
+type TaxUseCase interface {
+	GetRegions(ctx context.Context) ([]TaxRegion, error)
+	SetRegion(ctx context.Context, region *TaxRegion) error
+	DeleteRegion(ctx context.Context, code string) error
+	// Region returns the rates of the region, or of the default region when
+	// code is empty.
+	Region(ctx context.Context, code string) (*TaxRegion, error)
+	Calculate(ctx context.Context, region string, items []CartItem, discounts []DiscountLine) (*TaxBreakdown, error)
+}
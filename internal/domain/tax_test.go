@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMoney_IncludedTax(t *testing.T) {
+	assert.Equal(t, Money(7_00), Money(107_00).IncludedTax(7, RoundHalfEven))
+	assert.Equal(t, Money(6_54), Money(100_00).IncludedTax(7, RoundHalfEven))
+	assert.Equal(t, Money(0), Money(100_00).IncludedTax(0, RoundHalfEven))
+}
+
+func TestTaxableLines(t *testing.T) {
+	shirt, mug := primitive.NewObjectID(), primitive.NewObjectID()
+	variant := primitive.NewObjectID()
+	items := []CartItem{
+		{ProductId: shirt, VariantId: &variant, TotalPrice: 100_00},
+		{ProductId: mug, TotalPrice: 300_00, TaxClass: TaxClassZero},
+	}
+
+	lines := TaxableLines(items, []DiscountLine{
+		{Amount: 40_00},
+		{Amount: 20_00, Items: []DiscountAllocation{{ProductID: mug, Amount: 20_00}}},
+	})
+	assert.Equal(t, Money(89_47), lines[0].Amount)
+	assert.Equal(t, Money(250_53), lines[1].Amount)
+	assert.Equal(t, TaxClassZero, lines[1].Class)
+
+	lines = TaxableLines(items, []DiscountLine{
+		{Amount: 500_00, Items: []DiscountAllocation{{ProductID: shirt, VariantID: &variant, Amount: 500_00}}},
+	})
+	assert.Equal(t, Money(0), lines[0].Amount)
+	assert.Equal(t, Money(300_00), lines[1].Amount)
+}
+
+func TestTaxRegion_Validate(t *testing.T) {
+	region := DefaultTaxRegion()
+	assert.NoError(t, region.Validate())
+
+	rate, taxed := region.Rate("")
+	assert.True(t, taxed)
+	assert.Equal(t, 7.0, rate)
+	_, taxed = region.Rate(TaxClassExempt)
+	assert.False(t, taxed)
+
+	region.Rates[TaxClassExempt] = 0
+	assert.ErrorIs(t, region.Validate(), ErrInvalidTaxRegion)
+
+	region = TaxRegion{Code: "us-ca", Rates: map[TaxClass]float64{TaxClassStandard: 7.25}}
+	assert.ErrorIs(t, region.Validate(), ErrInvalidTaxRegion)
+	region.Code = "US-CA"
+	assert.NoError(t, region.Validate())
+}
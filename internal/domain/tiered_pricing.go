@@ -0,0 +1,69 @@
+package domain
+
+type TierBasis string
+
+const (
+	TierBasisQuantity TierBasis = "quantity"
+	TierBasisSpend    TierBasis = "spend"
+)
+
+// DiscountTier applies once the cart reaches Min items or Min spend. A tier
+// takes either Percentage off the qualifying items or a fixed Amount off.
+type DiscountTier struct {
+	Min        float64 `bson:"min" json:"min"`
+	Percentage float64 `bson:"percentage,omitempty" json:"percentage,omitempty"`
+	Amount     Money   `bson:"amount,omitempty" json:"amount,omitempty"`
+}
+
+// TieredPricing gives the discount of the highest tier the cart reaches,
+// counting only items in Category when one is set.
+type TieredPricing struct {
+	Basis    TierBasis      `json:"basis"`
+	Category string         `json:"category,omitempty"`
+	Tiers    []DiscountTier `json:"tiers"`
+}
+
+// Validate requires tiers in ascending order of Min, all of the same kind,
+// each giving more than the one before.
+func (p TieredPricing) Validate() error {
+	if p.Basis != TierBasisQuantity && p.Basis != TierBasisSpend {
+		return ErrInvalidTiers
+	}
+	if len(p.Tiers) == 0 {
+		return ErrInvalidTiers
+	}
+
+	byPercentage := p.Tiers[0].Percentage > 0
+	var previous DiscountTier
+	for i, tier := range p.Tiers {
+		if tier.Min <= 0 || (p.Basis == TierBasisQuantity && tier.Min != float64(int(tier.Min))) {
+			return ErrInvalidTiers
+		}
+		if (tier.Percentage > 0) == (tier.Amount > 0) || tier.Percentage < 0 || tier.Amount < 0 || tier.Percentage > 100 {
+			return ErrInvalidTiers
+		}
+		if (tier.Percentage > 0) != byPercentage {
+			return ErrInvalidTiers
+		}
+		if i > 0 && (tier.Min <= previous.Min || (tier.Percentage <= previous.Percentage && tier.Amount <= previous.Amount)) {
+			return ErrInvalidTiers
+		}
+		previous = tier
+	}
+	return nil
+}
+
+// Tier returns the highest tier reached by measure, or nil if none is.
+func (p TieredPricing) Tier(measure float64) *DiscountTier {
+	var reached *DiscountTier
+	for i := range p.Tiers {
+		if measure >= p.Tiers[i].Min {
+			reached = &p.Tiers[i]
+		}
+	}
+	return reached
+}
+
+func (r *DiscountRule) TieredPricing() TieredPricing {
+	return TieredPricing{Basis: r.TierBasis, Category: r.ItemCategory, Tiers: r.Tiers}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTieredPricing_Validate(t *testing.T) {
+	valid := TieredPricing{Basis: TierBasisQuantity, Tiers: []DiscountTier{{Min: 3, Percentage: 5}, {Min: 6, Percentage: 10}}}
+	assert.NoError(t, valid.Validate())
+
+	tests := map[string]TieredPricing{
+		"unknown basis":       {Basis: "weight", Tiers: valid.Tiers},
+		"no tiers":            {Basis: TierBasisSpend},
+		"thresholds unsorted": {Basis: TierBasisQuantity, Tiers: []DiscountTier{{Min: 6, Percentage: 10}, {Min: 3, Percentage: 5}}},
+		"discount shrinks":    {Basis: TierBasisSpend, Tiers: []DiscountTier{{Min: 1000, Amount: 300_00}, {Min: 2500, Amount: 100_00}}},
+		"mixed kinds":         {Basis: TierBasisSpend, Tiers: []DiscountTier{{Min: 1000, Amount: 100_00}, {Min: 2500, Percentage: 20}}},
+		"fractional quantity": {Basis: TierBasisQuantity, Tiers: []DiscountTier{{Min: 2.5, Percentage: 5}}},
+	}
+	for name, pricing := range tests {
+		assert.ErrorIs(t, pricing.Validate(), ErrInvalidTiers, name)
+	}
+}
+
+func TestTieredPricing_TierPicksHighestReached(t *testing.T) {
+	pricing := TieredPricing{Basis: TierBasisSpend, Tiers: []DiscountTier{{Min: 1000, Amount: 100_00}, {Min: 2500, Amount: 300_00}}}
+
+	assert.Nil(t, pricing.Tier(999))
+	assert.Equal(t, Money(100_00), pricing.Tier(1000).Amount)
+	assert.Equal(t, Money(300_00), pricing.Tier(4000).Amount)
+}
@@ -13,6 +13,7 @@ type User struct {
 	Email        string             `bson:"email" json:"email" validate:"required,email"`
 	Password     string             `bson:"password" json:"password,omitempty" validate:"required,min=6"`
 	Role         string             `bson:"role" json:"role"`
+	Points       int                `bson:"points" json:"points"`
 	RefreshToken string             `bson:"refresh_token,omitempty" json:"-"`
 	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
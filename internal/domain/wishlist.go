@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WishlistItem struct {
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID            primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	ProductID         primitive.ObjectID  `bson:"product_id" json:"product_id"`
+	VariantID         *primitive.ObjectID `bson:"variant_id,omitempty" json:"variant_id,omitempty"`
+	NotifyPriceDrop   bool                `bson:"notify_price_drop" json:"notify_price_drop"`
+	NotifyBackInStock bool                `bson:"notify_back_in_stock" json:"notify_back_in_stock"`
+	PriceWhenAdded    Money               `bson:"price_when_added" json:"price_when_added"`
+	LastNotifiedPrice *Money              `bson:"last_notified_price,omitempty" json:"-"`
+	CreatedAt         time.Time           `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time           `bson:"updated_at" json:"updated_at"`
+
+	ProductName  string `bson:"product_name,omitempty" json:"product_name"`
+	ProductSlug  string `bson:"product_slug,omitempty" json:"product_slug"`
+	ProductImage string `bson:"product_image,omitempty" json:"product_image"`
+	ProductPrice Money  `bson:"product_price,omitempty" json:"product_price"`
+	Available    bool   `bson:"available,omitempty" json:"available"`
+}
+
+// PriceDropReference is the price a new drop is measured against: the last
+// price the user was told about, or the price when they saved the item.
+func (w *WishlistItem) PriceDropReference() Money {
+	if w.LastNotifiedPrice != nil {
+		return *w.LastNotifiedPrice
+	}
+	return w.PriceWhenAdded
+}
+
+type WishlistPreferences struct {
+	NotifyPriceDrop   bool `json:"notify_price_drop"`
+	NotifyBackInStock bool `json:"notify_back_in_stock"`
+}
+
+type WishlistMove struct {
+	CartID   string `json:"cart_id" validate:"required"`
+	Quantity int    `json:"quantity" validate:"omitempty,min=1"`
+}
+
+type WishlistRepository interface {
+	Create(ctx context.Context, item *WishlistItem) error
+	FindByID(ctx context.Context, userID, id primitive.ObjectID) (*WishlistItem, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID, query ListQuery) ([]WishlistItem, *Pagination, error)
+	FindPriceWatchers(ctx context.Context, productID primitive.ObjectID) ([]WishlistItem, error)
+	FindStockWatchers(ctx context.Context, productID primitive.ObjectID, variantID *primitive.ObjectID) ([]WishlistItem, error)
+	UpdatePreferences(ctx context.Context, userID, id primitive.ObjectID, prefs WishlistPreferences) error
+	SetLastNotifiedPrice(ctx context.Context, id primitive.ObjectID, price Money) error
+	Delete(ctx context.Context, userID, id primitive.ObjectID) error
+}
+
+type WishlistUseCase interface {
+	Add(ctx context.Context, userID string, item *WishlistItem) error
+	GetByUserID(ctx context.Context, userID string, query ListQuery) ([]WishlistItem, *Pagination, error)
+	UpdatePreferences(ctx context.Context, userID, id string, prefs WishlistPreferences) error
+	Remove(ctx context.Context, userID, id string) error
+	MoveToCart(ctx context.Context, userID, id string, move WishlistMove) (*CartItem, error)
+	NotifyPriceDrop(ctx context.Context, change PriceChanged) error
+	NotifyBackInStock(ctx context.Context, restock BackInStock) error
+}
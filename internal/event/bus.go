@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"sync"
+)
+
+type Handler func(ctx context.Context, event domain.Event)
+
+// Bus is an in-process publisher. Handlers run on their own goroutine so a
+// slow subscriber never holds up the request that raised the event.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	wg       sync.WaitGroup
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *Bus) Publish(ctx context.Context, event domain.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.wg.Add(1)
+		go func(handler Handler) {
+			defer b.wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("event handler for %s panicked: %v", event.Type, r)
+				}
+			}()
+			handler(context.Background(), event)
+		}(handler)
+	}
+	return nil
+}
+
+// Wait blocks until every handler started so far has returned.
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
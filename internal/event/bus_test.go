@@ -0,0 +1,44 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishDeliversToSubscribers(t *testing.T) {
+	bus := NewBus()
+
+	var mu sync.Mutex
+	var received []string
+	for _, name := range []string{"first", "second"} {
+		name := name
+		bus.Subscribe(domain.EventProductLowStock, func(ctx context.Context, event domain.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, name+":"+event.Payload.(string))
+		})
+	}
+	bus.Subscribe("other", func(ctx context.Context, event domain.Event) {
+		t.Error("unexpected delivery to unrelated subscriber")
+	})
+
+	assert.NoError(t, bus.Publish(context.Background(), domain.NewEvent(domain.EventProductLowStock, "mug")))
+	bus.Wait()
+
+	assert.ElementsMatch(t, []string{"first:mug", "second:mug"}, received)
+}
+
+func TestBus_PanickingHandlerIsContained(t *testing.T) {
+	bus := NewBus()
+	bus.Subscribe(domain.EventProductLowStock, func(ctx context.Context, event domain.Event) {
+		panic("boom")
+	})
+
+	assert.NoError(t, bus.Publish(context.Background(), domain.NewEvent(domain.EventProductLowStock, nil)))
+	bus.Wait()
+}
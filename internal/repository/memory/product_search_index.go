@@ -0,0 +1,257 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/textsearch"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type indexedProduct struct {
+	product domain.Product
+	weights map[string]float64
+}
+
+type productSearchIndex struct {
+	mu       sync.RWMutex
+	products map[string]*indexedProduct
+	postings map[string]map[string]float64
+}
+
+func NewProductSearchIndex() domain.SearchIndex {
+	return &productSearchIndex{
+		products: make(map[string]*indexedProduct),
+		postings: make(map[string]map[string]float64),
+	}
+}
+
+func (idx *productSearchIndex) Index(ctx context.Context, product *domain.Product) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	id := product.ID.Hex()
+	idx.remove(id)
+	if product.DeletedAt != nil {
+		return nil
+	}
+
+	weights := make(map[string]float64)
+	for _, field := range []struct {
+		text   string
+		weight float64
+	}{
+		{product.Name, textsearch.NameWeight},
+		{product.Description, textsearch.DescriptionWeight},
+		{product.Content, textsearch.ContentWeight},
+	} {
+		for _, token := range textsearch.Tokenize(field.text) {
+			weights[token] += field.weight
+		}
+	}
+
+	idx.products[id] = &indexedProduct{product: *product, weights: weights}
+	for token, weight := range weights {
+		if idx.postings[token] == nil {
+			idx.postings[token] = make(map[string]float64)
+		}
+		idx.postings[token][id] = weight
+	}
+	return nil
+}
+
+func (idx *productSearchIndex) Remove(ctx context.Context, id string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+	return nil
+}
+
+func (idx *productSearchIndex) remove(id string) {
+	existing, ok := idx.products[id]
+	if !ok {
+		return
+	}
+	for token := range existing.weights {
+		delete(idx.postings[token], id)
+		if len(idx.postings[token]) == 0 {
+			delete(idx.postings, token)
+		}
+	}
+	delete(idx.products, id)
+}
+
+func (idx *productSearchIndex) Search(ctx context.Context, query domain.ProductSearchQuery) (*domain.ProductSearchResult, error) {
+	terms := textsearch.Tokenize(query.Query)
+	if len(terms) == 0 {
+		return nil, domain.ErrEmptySearchQuery
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	scores, fuzzy := idx.score(terms)
+
+	result := &domain.ProductSearchResult{
+		Hits:  []domain.ProductSearchHit{},
+		Fuzzy: fuzzy,
+		Facets: domain.SearchFacets{
+			Categories:   []domain.FacetCount{},
+			PriceBuckets: domain.NewPriceBucketCounts(),
+		},
+	}
+
+	categoryCounts := make(map[string]int64)
+	var hits []domain.ProductSearchHit
+	for id, score := range scores {
+		product := idx.products[id].product
+		if !matchesSearchFilters(product, query) {
+			continue
+		}
+		hits = append(hits, domain.ProductSearchHit{Product: product, Score: score})
+		if !product.CategoryID.IsZero() {
+			categoryCounts[product.CategoryID.Hex()]++
+		}
+		result.Facets.PriceBuckets[domain.PriceBucketIndex(product.Price)].Count++
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Sold > hits[j].Sold
+	})
+
+	for value, count := range categoryCounts {
+		result.Facets.Categories = append(result.Facets.Categories, domain.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(result.Facets.Categories, func(i, j int) bool {
+		return result.Facets.Categories[i].Count > result.Facets.Categories[j].Count
+	})
+
+	result.Total = int64(len(hits))
+	start := minInt(query.Offset, len(hits))
+	end := len(hits)
+	if query.Limit > 0 {
+		end = minInt(start+query.Limit, len(hits))
+	}
+	result.Hits = append(result.Hits, hits[start:end]...)
+	return result, nil
+}
+
+// score requires every term to match, falling back to typo-tolerant and
+// prefix matches for terms that have no exact posting.
+func (idx *productSearchIndex) score(terms []string) (map[string]float64, bool) {
+	var scores map[string]float64
+	fuzzy := false
+
+	for i, term := range terms {
+		termScores := make(map[string]float64)
+		for id, weight := range idx.postings[term] {
+			termScores[id] += weight * idx.idf(term)
+		}
+
+		if len(termScores) == 0 {
+			isLast := i == len(terms)-1
+			for token, posting := range idx.postings {
+				var factor float64
+				switch {
+				case isLast && strings.HasPrefix(token, term):
+					factor = 0.8
+				case textsearch.Distance(term, token) <= textsearch.MaxEdits(term):
+					factor = 0.5
+				default:
+					continue
+				}
+				fuzzy = true
+				for id, weight := range posting {
+					termScores[id] = math.Max(termScores[id], weight*idx.idf(token)*factor)
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+		for id := range scores {
+			if s, ok := termScores[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+	return scores, fuzzy
+}
+
+func (idx *productSearchIndex) idf(token string) float64 {
+	return 1 + math.Log(float64(len(idx.products)+1)/float64(len(idx.postings[token])+1))
+}
+
+func (idx *productSearchIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return nil, domain.ErrEmptySearchQuery
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	type suggestion struct {
+		name string
+		sold int
+	}
+	var matches []suggestion
+	for _, indexed := range idx.products {
+		name := indexed.product.Name
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, prefix) || strings.Contains(lower, " "+prefix) {
+			matches = append(matches, suggestion{name: name, sold: indexed.product.Sold})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].sold != matches[j].sold {
+			return matches[i].sold > matches[j].sold
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	suggestions := []string{}
+	for _, match := range matches {
+		if limit > 0 && len(suggestions) >= limit {
+			break
+		}
+		suggestions = append(suggestions, match.name)
+	}
+	return suggestions, nil
+}
+
+func matchesSearchFilters(product domain.Product, query domain.ProductSearchQuery) bool {
+	if query.MinPrice != nil && product.Price < *query.MinPrice {
+		return false
+	}
+	if query.MaxPrice != nil && product.Price > *query.MaxPrice {
+		return false
+	}
+	if len(query.CategoryIDs) == 0 {
+		return true
+	}
+	for _, categoryID := range query.CategoryIDs {
+		if product.CategoryID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
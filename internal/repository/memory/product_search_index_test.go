@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/internal/repository/searchtest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newIndexedProducts(t *testing.T) (domain.SearchIndex, primitive.ObjectID, primitive.ObjectID) {
+	idx := NewProductSearchIndex()
+	shirts := primitive.NewObjectID()
+	shoes := primitive.NewObjectID()
+
+	products := []domain.Product{
+		{ID: primitive.NewObjectID(), Name: "Linen Shirt", Description: "Breathable summer shirt", Content: "linen", Price: 890_00, CategoryID: shirts, Sold: 5},
+		{ID: primitive.NewObjectID(), Name: "Oxford Shirt", Description: "Classic cotton", Content: "cotton", Price: 1290_00, CategoryID: shirts, Sold: 12},
+		{ID: primitive.NewObjectID(), Name: "Running Shoes", Description: "Light shoes with a shirt-friendly colourway", Content: "mesh", Price: 2490_00, CategoryID: shoes},
+	}
+	for i := range products {
+		assert.NoError(t, idx.Index(context.Background(), &products[i]))
+	}
+	return idx, shirts, shoes
+}
+
+func TestProductSearchIndex_RanksNameMatchesFirst(t *testing.T) {
+	idx, _, _ := newIndexedProducts(t)
+
+	result, err := idx.Search(context.Background(), domain.ProductSearchQuery{Query: "shirt"})
+	assert.NoError(t, err)
+	assert.False(t, result.Fuzzy)
+	assert.EqualValues(t, 3, result.Total)
+	assert.Equal(t, "Running Shoes", result.Hits[2].Name)
+}
+
+func TestProductSearchIndex_ToleratesTypos(t *testing.T) {
+	idx, _, _ := newIndexedProducts(t)
+
+	result, err := idx.Search(context.Background(), domain.ProductSearchQuery{Query: "oxfrod"})
+	assert.NoError(t, err)
+	assert.True(t, result.Fuzzy)
+	assert.EqualValues(t, 1, result.Total)
+	assert.Equal(t, "Oxford Shirt", result.Hits[0].Name)
+}
+
+func TestProductSearchIndex_Facets(t *testing.T) {
+	idx, shirts, _ := newIndexedProducts(t)
+
+	result, err := idx.Search(context.Background(), domain.ProductSearchQuery{Query: "shirt"})
+	assert.NoError(t, err)
+	assert.Equal(t, shirts.Hex(), result.Facets.Categories[0].Value)
+	assert.EqualValues(t, 2, result.Facets.Categories[0].Count)
+	assert.EqualValues(t, 1, result.Facets.PriceBuckets[domain.PriceBucketIndex(890_00)].Count)
+	assert.EqualValues(t, 2, result.Facets.PriceBuckets[domain.PriceBucketIndex(1290_00)].Count)
+}
+
+func TestProductSearchIndex_FiltersAndRemoves(t *testing.T) {
+	idx, _, shoes := newIndexedProducts(t)
+
+	result, err := idx.Search(context.Background(), domain.ProductSearchQuery{Query: "shirt", CategoryIDs: []primitive.ObjectID{shoes}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, result.Total)
+
+	assert.NoError(t, idx.Remove(context.Background(), result.Hits[0].ID.Hex()))
+	result, err = idx.Search(context.Background(), domain.ProductSearchQuery{Query: "shirt", CategoryIDs: []primitive.ObjectID{shoes}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, result.Total)
+}
+
+func TestProductSearchIndex_Suggest(t *testing.T) {
+	idx, _, _ := newIndexedProducts(t)
+
+	suggestions, err := idx.Suggest(context.Background(), "sh", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Oxford Shirt", "Linen Shirt", "Running Shoes"}, suggestions)
+}
+
+func TestProductSearchIndex_MatchesEveryTerm(t *testing.T) {
+	searchtest.RunMultiTerm(t, func(t *testing.T, products []domain.Product) domain.SearchIndex {
+		idx := NewProductSearchIndex()
+		for i := range products {
+			require.NoError(t, idx.Index(context.Background(), &products[i]))
+		}
+		return idx
+	})
+}
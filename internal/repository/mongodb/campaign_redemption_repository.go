@@ -0,0 +1,57 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type campaignRedemptionRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewCampaignRedemptionRepository(db *mongo.Database) domain.CampaignRedemptionRepository {
+	return &campaignRedemptionRepository{
+		db:   db,
+		coll: db.Collection("campaign_redemptions"),
+	}
+}
+
+// Claim increments the customer's counter with an upsert that only matches
+// while the counter is under limit. Once it is at the limit the upsert tries
+// to insert a second document for the pair and the unique index refuses it.
+func (r *campaignRedemptionRepository) Claim(ctx context.Context, campaignID, userID primitive.ObjectID, limit int) (bool, error) {
+	filter := bson.M{"campaign_id": campaignID, "user_id": userID}
+	if limit > 0 {
+		filter["count"] = bson.M{"$lt": limit}
+	}
+
+	_, err := r.coll.UpdateOne(
+		ctx,
+		filter,
+		bson.M{"$inc": bson.M{"count": 1}, "$set": bson.M{"updated_at": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *campaignRedemptionRepository) Release(ctx context.Context, campaignID, userID primitive.ObjectID) error {
+	_, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"campaign_id": campaignID, "user_id": userID, "count": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"count": -1}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	return err
+}
@@ -5,8 +5,10 @@ import (
 	"play-to-win-api/internal/domain"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type campaignRepository struct {
@@ -38,35 +40,206 @@ func (r *campaignRepository) FindByID(ctx context.Context, id string) (*domain.C
 		return nil, err
 	}
 	var campaign domain.Campaign
-	err = r.coll.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&campaign)
+	err = r.coll.FindOne(ctx, live(bson.M{"_id": objectID})).Decode(&campaign)
 	return &campaign, err
 }
 
-func (r *campaignRepository) FindAll(ctx context.Context) ([]domain.Campaign, error) {
-	cursor, err := r.coll.Find(ctx, primitive.M{})
-	if err != nil {
-		return nil, err
+var campaignListSpec = listSpec{
+	sortable: map[string]string{
+		"name":       "name",
+		"start_date": "start_date",
+		"end_date":   "end_date",
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *campaignRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.Campaign, *domain.Pagination, error) {
+	filter := live(bson.M{})
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Category != "" {
+		filter["category"] = q.Filter.Category
 	}
-	var campaigns []domain.Campaign
-	err = cursor.All(ctx, &campaigns)
-	return campaigns, err
+
+	return listPage[domain.Campaign](ctx, r.coll, filter, q, campaignListSpec)
 }
 
 func (r *campaignRepository) Update(ctx context.Context, c *domain.Campaign) error {
 	c.UpdatedAt = time.Now()
+	update := bson.M{"$set": c}
+	unset := bson.M{}
+	if c.StartedAt == nil {
+		unset["started_at"] = ""
+	}
+	if c.EndedAt == nil {
+		unset["ended_at"] = ""
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	_, err := r.coll.UpdateOne(ctx, live(bson.M{"_id": c.ID}), update)
+	return err
+}
+
+func (r *campaignRepository) FindDueToStart(ctx context.Context, at time.Time) ([]domain.Campaign, error) {
+	return r.find(ctx, live(bson.M{
+		"is_active":  false,
+		"started_at": nil,
+		"start_date": bson.M{"$lte": at},
+		"end_date":   bson.M{"$gt": at},
+	}))
+}
+
+func (r *campaignRepository) FindDueToEnd(ctx context.Context, at time.Time) ([]domain.Campaign, error) {
+	return r.find(ctx, live(bson.M{
+		"is_active": true,
+		"end_date":  bson.M{"$lte": at},
+	}))
+}
+
+// Redeem only matches a campaign that still has budget and redemptions left.
+// The budget is charged at most up to its cap, so concurrent checkouts can
+// never overspend it; the last one simply gets a partial grant.
+func (r *campaignRepository) Redeem(ctx context.Context, id primitive.ObjectID, amount domain.Money, at time.Time) (*domain.Redemption, error) {
+	spent := bson.M{"$ifNull": bson.A{"$spent", 0}}
+	redemptions := bson.M{"$ifNull": bson.A{"$redemptions", 0}}
+	filter := live(bson.M{
+		"_id":       id,
+		"is_active": true,
+		"$expr": bson.M{"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"$lte": bson.A{"$budget", 0}},
+				bson.M{"$lt": bson.A{spent, "$budget"}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"$lte": bson.A{"$max_redemptions", 0}},
+				bson.M{"$lt": bson.A{redemptions, "$max_redemptions"}},
+			}},
+		}},
+	})
+
+	charged := bson.M{"$add": bson.A{spent, amount}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"spent": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$budget", 0}},
+				bson.M{"$min": bson.A{charged, "$budget"}},
+				charged,
+			}},
+			"redemptions": bson.M{"$add": bson.A{redemptions, 1}},
+			"updated_at":  at,
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"exhausted": bson.M{"$or": bson.A{
+				bson.M{"$and": bson.A{bson.M{"$gt": bson.A{"$budget", 0}}, bson.M{"$gte": bson.A{"$spent", "$budget"}}}},
+				bson.M{"$and": bson.A{bson.M{"$gt": bson.A{"$max_redemptions", 0}}, bson.M{"$gte": bson.A{"$redemptions", "$max_redemptions"}}}},
+			}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"is_active":    bson.M{"$not": bson.A{"$exhausted"}},
+			"exhausted_at": bson.M{"$cond": bson.A{"$exhausted", at, "$$REMOVE"}},
+		}}},
+		{{Key: "$unset", Value: "exhausted"}},
+	}
+
+	var before domain.Campaign
+	err := r.coll.FindOneAndUpdate(ctx, filter, update).Decode(&before)
+	if err == mongo.ErrNoDocuments {
+		return &domain.Redemption{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	redemption := &domain.Redemption{Granted: amount}
+	if before.Budget > 0 {
+		redemption.Granted = domain.MinMoney(amount, before.Budget-before.Spent)
+		redemption.Exhausted = before.Spent+redemption.Granted >= before.Budget
+	}
+	if before.MaxRedemptions > 0 && before.Redemptions+1 >= before.MaxRedemptions {
+		redemption.Exhausted = true
+	}
+	return redemption, nil
+}
+
+// Unredeem gives back what Redeem took. A campaign it had switched off stays
+// off; marketing can raise the cap and reactivate it deliberately.
+func (r *campaignRepository) Unredeem(ctx context.Context, id primitive.ObjectID, amount domain.Money) error {
 	_, err := r.coll.UpdateOne(
 		ctx,
-		primitive.M{"_id": c.ID},
-		primitive.M{"$set": c},
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"spent": -amount, "redemptions": -1}},
 	)
 	return err
 }
 
+func (r *campaignRepository) TargetsSegment(ctx context.Context, segmentID primitive.ObjectID) (bool, error) {
+	count, err := r.coll.CountDocuments(ctx, live(bson.M{"segment_ids": segmentID}), options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+func (r *campaignRepository) FindOverlapping(ctx context.Context, from, to time.Time) ([]domain.Campaign, error) {
+	return r.find(ctx, live(bson.M{
+		"start_date": bson.M{"$lt": to},
+		"end_date":   bson.M{"$gt": from},
+	}))
+}
+
+func (r *campaignRepository) find(ctx context.Context, filter bson.M) ([]domain.Campaign, error) {
+	cursor, err := r.coll.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "start_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	campaigns := []domain.Campaign{}
+	err = cursor.All(ctx, &campaigns)
+	return campaigns, err
+}
+
+func (r *campaignRepository) MarkStarted(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	result, err := r.coll.UpdateOne(
+		ctx,
+		live(bson.M{"_id": id, "is_active": false, "started_at": nil}),
+		bson.M{"$set": bson.M{"is_active": true, "started_at": at, "updated_at": at}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *campaignRepository) MarkEnded(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	result, err := r.coll.UpdateOne(
+		ctx,
+		live(bson.M{"_id": id, "is_active": true}),
+		bson.M{"$set": bson.M{"is_active": false, "ended_at": at, "updated_at": at}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
 func (r *campaignRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
-	_, err = r.coll.DeleteOne(ctx, primitive.M{"_id": objectID})
-	return err
+	return softDelete(ctx, r.coll, objectID, domain.ErrCampaignNotFound)
+}
+
+func (r *campaignRepository) Restore(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidCampaignID
+	}
+	return restoreArchived(ctx, r.coll, objectID, domain.ErrCampaignNotFound)
+}
+
+func (r *campaignRepository) FindArchived(ctx context.Context, q domain.ListQuery) ([]domain.Campaign, *domain.Pagination, error) {
+	return listPage[domain.Campaign](ctx, r.coll, archived(bson.M{}), q, archivedListSpec)
+}
+
+func (r *campaignRepository) PurgeArchived(ctx context.Context, before time.Time) (int64, error) {
+	return purgeArchived(ctx, r.coll, before)
 }
@@ -39,40 +39,13 @@ func (r *cartItemRepository) FindByCartID(ctx context.Context, cartID string) ([
 		return nil, domain.ErrInvalidCartItemID
 	}
 
-	pipeline := []bson.M{
+	pipeline := append([]bson.M{
 		{
 			"$match": bson.M{
 				"cart_id": objectID,
 			},
 		},
-		{
-			"$lookup": bson.M{
-				"from":         "products",
-				"localField":   "product_id",
-				"foreignField": "_id",
-				"as":           "product",
-			},
-		},
-		{
-			"$unwind": bson.M{
-				"path":                       "$product",
-				"preserveNullAndEmptyArrays": true,
-			},
-		},
-		{
-			"$addFields": bson.M{
-				"product_name":        "$product.name",
-				"product_description": "$product.description",
-				"product_image":       "$product.image",
-				"product_price":       "$product.price",
-			},
-		},
-		{
-			"$project": bson.M{
-				"product": 0,
-			},
-		},
-	}
+	}, productLookupStages()...)
 
 	cursor, err := r.coll.Aggregate(ctx, pipeline)
 	if err != nil {
@@ -94,40 +67,13 @@ func (r *cartItemRepository) FindByID(ctx context.Context, id string) (*domain.C
 		return nil, domain.ErrInvalidCartItemID
 	}
 
-	pipeline := []bson.M{
+	pipeline := append([]bson.M{
 		{
 			"$match": bson.M{
 				"_id": objectID,
 			},
 		},
-		{
-			"$lookup": bson.M{
-				"from":         "products",
-				"localField":   "product_id",
-				"foreignField": "_id",
-				"as":           "product",
-			},
-		},
-		{
-			"$unwind": bson.M{
-				"path":                       "$product",
-				"preserveNullAndEmptyArrays": true,
-			},
-		},
-		{
-			"$addFields": bson.M{
-				"product_name":        "$product.name",
-				"product_description": "$product.description",
-				"product_image":       "$product.image",
-				"product_price":       "$product.price",
-			},
-		},
-		{
-			"$project": bson.M{
-				"product": 0,
-			},
-		},
-	}
+	}, productLookupStages()...)
 
 	var cartItems []domain.CartItem
 	cursor, err := r.coll.Aggregate(ctx, pipeline)
@@ -147,8 +93,45 @@ func (r *cartItemRepository) FindByID(ctx context.Context, id string) (*domain.C
 	return &cartItems[0], nil
 }
 
-func (r *cartItemRepository) FindAll(ctx context.Context) ([]domain.CartItem, error) {
-	pipeline := []bson.M{
+var cartItemListSpec = listSpec{
+	sortable: map[string]string{
+		"quantity":    "quantity",
+		"unit_price":  "unit_price",
+		"total_price": "total_price",
+		"created_at":  "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *cartItemRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.CartItem, *domain.Pagination, error) {
+	filter := bson.M{}
+	applyPriceRange(filter, "unit_price", q.Filter)
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Category != "" {
+		filter["category"] = q.Filter.Category
+	}
+
+	return listPage[domain.CartItem](ctx, r.coll, filter, q, cartItemListSpec, productLookupStages()...)
+}
+
+// productLookupStages resolves product details for each cart item, preferring
+// the selected variant's image and price over the product's own.
+func productLookupStages() []bson.M {
+	return lookupProduct(bson.M{
+		"product_name":        "$product.name",
+		"product_description": "$product.description",
+		"product_image":       bson.M{"$ifNull": bson.A{"$variant.image", "$product.image"}},
+		"product_price":       bson.M{"$ifNull": bson.A{"$variant.price", "$product.price"}},
+		"on_sale":             bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$product.was_price", 0}}, "$product.price"}},
+		"variant_sku":         "$variant.sku",
+		"variant_options":     "$variant.options",
+	})
+}
+
+// lookupProduct joins the product and variant referenced by product_id and
+// variant_id, exposing them as $product and $variant to the given fields.
+func lookupProduct(fields bson.M) []bson.M {
+	return []bson.M{
 		{
 			"$lookup": bson.M{
 				"from":         "products",
@@ -165,31 +148,26 @@ func (r *cartItemRepository) FindAll(ctx context.Context) ([]domain.CartItem, er
 		},
 		{
 			"$addFields": bson.M{
-				"product_name":        "$product.name",
-				"product_description": "$product.description",
-				"product_image":       "$product.image",
-				"product_price":       "$product.price",
+				"variant": bson.M{
+					"$arrayElemAt": bson.A{
+						bson.M{"$filter": bson.M{
+							"input": bson.M{"$ifNull": bson.A{"$product.variants", bson.A{}}},
+							"as":    "variant",
+							"cond":  bson.M{"$eq": bson.A{"$$variant._id", "$variant_id"}},
+						}},
+						0,
+					},
+				},
 			},
 		},
+		{"$addFields": fields},
 		{
 			"$project": bson.M{
 				"product": 0,
+				"variant": 0,
 			},
 		},
 	}
-
-	cursor, err := r.coll.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var cartItems []domain.CartItem
-	if err = cursor.All(ctx, &cartItems); err != nil {
-		return nil, err
-	}
-
-	return cartItems, nil
 }
 
 func (r *cartItemRepository) Update(ctx context.Context, cartItem *domain.CartItem) error {
@@ -92,14 +92,20 @@ func (r *cartRepository) FindByID(ctx context.Context, id string) (*domain.Cart,
 	return &cart, err
 }
 
-func (r *cartRepository) FindAll(ctx context.Context) ([]domain.Cart, error) {
-	cursor, err := r.coll.Find(ctx, primitive.M{})
-	if err != nil {
-		return nil, err
-	}
-	var carts []domain.Cart
-	err = cursor.All(ctx, &carts)
-	return carts, err
+var cartListSpec = listSpec{
+	sortable: map[string]string{
+		"total_amount": "total_amount",
+		"created_at":   "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *cartRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.Cart, *domain.Pagination, error) {
+	filter := bson.M{}
+	applyPriceRange(filter, "total_amount", q.Filter)
+	applyCreatedRange(filter, q.Filter)
+
+	return listPage[domain.Cart](ctx, r.coll, filter, q, cartListSpec)
 }
 
 func (r *cartRepository) Update(ctx context.Context, cart *domain.Cart) error {
@@ -3,10 +3,13 @@ package mongodb
 import (
 	"context"
 	"play-to-win-api/internal/domain"
+	"regexp"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type categoryRepository struct {
@@ -38,12 +41,29 @@ func (r *categoryRepository) FindByID(ctx context.Context, id string) (*domain.C
 		return nil, err
 	}
 	var category domain.Category
-	err = r.coll.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&category)
+	err = r.coll.FindOne(ctx, live(bson.M{"_id": objectID})).Decode(&category)
 	return &category, err
 }
 
-func (r *categoryRepository) FindAll(ctx context.Context) ([]domain.Category, error) {
-	cursor, err := r.coll.Find(ctx, primitive.M{})
+func (r *categoryRepository) FindByName(ctx context.Context, name string) (*domain.Category, error) {
+	var category domain.Category
+	err := r.coll.FindOne(ctx, live(bson.M{"name": name})).Decode(&category)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrCategoryNotFound
+	}
+	return &category, err
+}
+
+func (r *categoryRepository) FindBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	return findBySlug[domain.Category](ctx, r.coll, slug, domain.ErrCategoryNotFound)
+}
+
+func (r *categoryRepository) SlugTaken(ctx context.Context, slug string, exclude primitive.ObjectID) (bool, error) {
+	return slugTaken(ctx, r.coll, slug, exclude)
+}
+
+func (r *categoryRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Category, error) {
+	cursor, err := r.coll.Find(ctx, live(bson.M{"_id": bson.M{"$in": ids}}))
 	if err != nil {
 		return nil, err
 	}
@@ -52,11 +72,49 @@ func (r *categoryRepository) FindAll(ctx context.Context) ([]domain.Category, er
 	return categories, err
 }
 
+func (r *categoryRepository) FindByPathPrefix(ctx context.Context, prefix string) ([]domain.Category, error) {
+	filter := live(bson.M{"path": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)}})
+	opts := options.Find().SetSort(bson.D{{Key: "depth", Value: 1}, {Key: "name", Value: 1}})
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var categories []domain.Category
+	err = cursor.All(ctx, &categories)
+	return categories, err
+}
+
+func (r *categoryRepository) HasChildren(ctx context.Context, id string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	count, err := r.coll.CountDocuments(ctx, live(bson.M{"parent_id": objectID}), options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+var categoryListSpec = listSpec{
+	sortable: map[string]string{
+		"name":       "name",
+		"depth":      "depth",
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "name"},
+}
+
+func (r *categoryRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.Category, *domain.Pagination, error) {
+	filter := live(bson.M{})
+	applyCreatedRange(filter, q.Filter)
+
+	return listPage[domain.Category](ctx, r.coll, filter, q, categoryListSpec)
+}
+
 func (r *categoryRepository) Update(ctx context.Context, c *domain.Category) error {
 	c.UpdatedAt = time.Now()
 	_, err := r.coll.UpdateOne(
 		ctx,
-		primitive.M{"_id": c.ID},
+		live(bson.M{"_id": c.ID}),
 		primitive.M{"$set": c},
 	)
 	return err
@@ -67,6 +125,39 @@ func (r *categoryRepository) Delete(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
-	_, err = r.coll.DeleteOne(ctx, primitive.M{"_id": objectID})
-	return err
+	return softDelete(ctx, r.coll, objectID, domain.ErrCategoryNotFound)
+}
+
+func (r *categoryRepository) Restore(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidCategoryID
+	}
+	return restoreArchived(ctx, r.coll, objectID, domain.ErrCategoryNotFound)
+}
+
+func (r *categoryRepository) FindArchived(ctx context.Context, q domain.ListQuery) ([]domain.Category, *domain.Pagination, error) {
+	return listPage[domain.Category](ctx, r.coll, archived(bson.M{}), q, archivedListSpec)
+}
+
+func (r *categoryRepository) FindArchivedByID(ctx context.Context, id string) (*domain.Category, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidCategoryID
+	}
+
+	var category domain.Category
+	err = r.coll.FindOne(ctx, archived(bson.M{"_id": objectID})).Decode(&category)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrCategoryNotFound
+	}
+	return &category, err
+}
+
+func (r *categoryRepository) PurgeArchived(ctx context.Context, before time.Time) (int64, error) {
+	return purgeArchived(ctx, r.coll, before)
+}
+
+func (r *categoryRepository) Each(ctx context.Context, fn func(*domain.Category) error) error {
+	return eachDocument(ctx, r.coll, live(bson.M{}), bson.D{{Key: "depth", Value: 1}, {Key: "name", Value: 1}}, fn)
 }
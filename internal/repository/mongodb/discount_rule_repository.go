@@ -43,8 +43,22 @@ func (r *discountRuleRepository) FindByID(ctx context.Context, id string) (*doma
 	return &discountRule, err
 }
 
-func (r *discountRuleRepository) FindAll(ctx context.Context) ([]domain.DiscountRule, error) {
-	pipeline := []bson.M{
+var discountRuleListSpec = listSpec{
+	sortable: map[string]string{
+		"discount_type": "discount_type",
+		"created_at":    "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *discountRuleRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.DiscountRule, *domain.Pagination, error) {
+	filter := bson.M{}
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Category != "" {
+		filter["item_category"] = q.Filter.Category
+	}
+
+	lookup := []bson.M{
 		{
 			"$lookup": bson.M{
 				"from":         "campaigns",
@@ -60,41 +74,65 @@ func (r *discountRuleRepository) FindAll(ctx context.Context) ([]domain.Discount
 			},
 		},
 		{
-			"$project": bson.M{
-				"_id":                           1,
-				"campaign_id":                   1,
-				"discount_type":                 1,
-				"amount":                        1,
-				"percentage":                    1,
-				"item_category":                 1,
-				"points_ratio":                  1,
-				"max_discount_percentage":       1,
-				"threshold_amount":              1,
-				"discount_percentage_threshold": 1,
-				"created_at":                    1,
-				"updated_at":                    1,
-				"campaign_name":                 "$campaign.name",
-			},
+			"$set": bson.M{"campaign_name": "$campaign.name"},
 		},
 		{
-			"$sort": bson.M{
-				"created_at": -1,
-			},
+			"$unset": "campaign",
 		},
 	}
 
+	return listPage[domain.DiscountRule](ctx, r.coll, filter, q, discountRuleListSpec, lookup...)
+}
+
+// liveCampaignAt matches campaigns whose discounts apply at the given time.
+// It mirrors domain.Campaign.Live so a campaign the scheduler has not yet
+// switched off still stops discounting the moment its window closes.
+func liveCampaignAt(prefix string, at time.Time) bson.M {
+	return bson.M{
+		prefix + "is_active":  true,
+		prefix + "deleted_at": nil,
+		prefix + "start_date": bson.M{"$lte": at},
+		prefix + "end_date":   bson.M{"$gt": at},
+	}
+}
+
+func (r *discountRuleRepository) FindLive(ctx context.Context, at time.Time) ([]domain.DiscountRule, error) {
+	pipeline := []bson.M{
+		{"$lookup": bson.M{
+			"from":         "campaigns",
+			"localField":   "campaign_id",
+			"foreignField": "_id",
+			"as":           "campaign",
+		}},
+		{"$unwind": "$campaign"},
+		{"$match": liveCampaignAt("campaign.", at)},
+		{"$sort": bson.D{{Key: "campaign.priority", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{"$set": bson.M{
+			"campaign_name":        "$campaign.name",
+			"campaign_priority":    "$campaign.priority",
+			"campaign_exclusive":   "$campaign.exclusive",
+			"campaign_segment_ids": "$campaign.segment_ids",
+		}},
+		{"$unset": "campaign"},
+	}
+
 	cursor, err := r.coll.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
+	rules := []domain.DiscountRule{}
+	err = cursor.All(ctx, &rules)
+	return rules, err
+}
 
-	var discountRules []domain.DiscountRule
-	if err = cursor.All(ctx, &discountRules); err != nil {
+func (r *discountRuleRepository) FindByCampaignIDs(ctx context.Context, campaignIDs []primitive.ObjectID) ([]domain.DiscountRule, error) {
+	cursor, err := r.coll.Find(ctx, bson.M{"campaign_id": bson.M{"$in": campaignIDs}})
+	if err != nil {
 		return nil, err
 	}
-
-	return discountRules, nil
+	rules := []domain.DiscountRule{}
+	err = cursor.All(ctx, &rules)
+	return rules, err
 }
 
 func (r *discountRuleRepository) Update(ctx context.Context, discountRule *domain.DiscountRule) error {
@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDiscountRuleRepository_FindAllKeepsRuleSettings(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	campaign := &domain.Campaign{Name: "Summer"}
+	require.NoError(t, NewCampaignRepository(db).Create(ctx, campaign))
+
+	mug := primitive.NewObjectID()
+	rules := []domain.DiscountRule{
+		{DiscuntType: domain.DiscountTypeBOGO, BuyProductIDs: []primitive.ObjectID{mug}, GetCategory: "Coasters", Percentage: 50},
+		{DiscuntType: domain.DiscountTypeTiered, TierBasis: domain.TierBasisQuantity, Tiers: []domain.DiscountTier{{Min: 2, Percentage: 5}, {Min: 5, Percentage: 10}}},
+		{DiscuntType: domain.DiscountTypePercentage, Percentage: 10, Condition: `cart.subtotal >= 500`},
+		{DiscuntType: domain.DiscountTypeFixedAmount, Amount: 5_00, Currency: "USD"},
+	}
+	repo := NewDiscountRuleRepository(db)
+	for i := range rules {
+		rules[i].CampaignID = campaign.ID
+		require.NoError(t, repo.Create(ctx, &rules[i]))
+	}
+
+	listed, _, err := repo.FindAll(ctx, domain.ListQuery{})
+	require.NoError(t, err)
+	require.Len(t, listed, len(rules))
+
+	byID := make(map[primitive.ObjectID]domain.DiscountRule, len(listed))
+	for _, rule := range listed {
+		assert.Equal(t, "Summer", rule.CampaignName)
+		byID[rule.ID] = rule
+	}
+	for _, want := range rules {
+		got := byID[want.ID]
+		assert.Equal(t, want.DiscuntType, got.DiscuntType)
+		assert.Equal(t, want.BuyProductIDs, got.BuyProductIDs)
+		assert.Equal(t, want.GetCategory, got.GetCategory)
+		assert.Equal(t, want.Percentage, got.Percentage)
+		assert.Equal(t, want.TierBasis, got.TierBasis)
+		assert.Equal(t, want.Tiers, got.Tiers)
+		assert.Equal(t, want.Condition, got.Condition)
+		assert.Equal(t, want.Amount, got.Amount)
+		assert.Equal(t, want.Currency, got.Currency)
+	}
+}
@@ -0,0 +1,73 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type exchangeRateRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+// NewExchangeRateRepository keeps one document per currency, keyed by its
+// code.
+func NewExchangeRateRepository(db *mongo.Database) domain.ExchangeRateRepository {
+	return &exchangeRateRepository{
+		db:   db,
+		coll: db.Collection("exchange_rates"),
+	}
+}
+
+func (r *exchangeRateRepository) FindAll(ctx context.Context) ([]domain.ExchangeRate, error) {
+	cursor, err := r.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	rates := []domain.ExchangeRate{}
+	err = cursor.All(ctx, &rates)
+	return rates, err
+}
+
+func (r *exchangeRateRepository) FindByCurrency(ctx context.Context, currency domain.Currency) (*domain.ExchangeRate, error) {
+	var rate domain.ExchangeRate
+	err := r.coll.FindOne(ctx, bson.M{"_id": currency}).Decode(&rate)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrExchangeRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *exchangeRateRepository) Upsert(ctx context.Context, rate *domain.ExchangeRate) error {
+	rate.UpdatedAt = time.Now()
+	_, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": rate.Currency},
+		bson.M{"$set": bson.M{
+			"rate":       rate.Rate,
+			"updated_by": rate.UpdatedBy,
+			"updated_at": rate.UpdatedAt,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *exchangeRateRepository) Delete(ctx context.Context, currency domain.Currency) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": currency})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrExchangeRateNotFound
+	}
+	return nil
+}
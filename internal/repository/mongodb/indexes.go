@@ -0,0 +1,128 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/pkg/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var collectionIndexes = map[string][]mongo.IndexModel{
+	"categories": {
+		{Keys: bson.D{{Key: "path", Value: 1}}},
+		{Keys: bson.D{{Key: "parent_id", Value: 1}}},
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"slug": bson.M{"$exists": true},
+			}),
+		},
+		{Keys: bson.D{{Key: "previous_slugs", Value: 1}}},
+	},
+	"products": {
+		{Keys: bson.D{{Key: "category_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "price", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "stock", Value: 1}}},
+		{Keys: bson.D{{Key: "rating_average", Value: 1}, {Key: "_id", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "sku", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"sku": bson.M{"$exists": true},
+			}),
+		},
+		{
+			Keys: bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"slug": bson.M{"$exists": true},
+			}),
+		},
+		{Keys: bson.D{{Key: "previous_slugs", Value: 1}}},
+		{
+			Keys: bson.D{{Key: "variants.sku", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"variants.sku": bson.M{"$exists": true},
+			}),
+		},
+		{
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}, {Key: "content", Value: "text"}},
+			Options: options.Index().SetName("product_text").SetWeights(bson.D{
+				{Key: "name", Value: textsearch.NameWeight},
+				{Key: "description", Value: textsearch.DescriptionWeight},
+				{Key: "content", Value: textsearch.ContentWeight},
+			}),
+		},
+	},
+	"reviews": {
+		{
+			Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	},
+	"stock_movements": {
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+	"price_changes": {
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "effective_from", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "effective_from", Value: 1}}},
+	},
+	"wishlist_items": {
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "variant_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "product_id", Value: 1}}},
+	},
+	"product_recommendations": {
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "score", Value: -1}}},
+	},
+	"campaign_redemptions": {
+		{Keys: bson.D{{Key: "campaign_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	"orders": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "discounts.campaign_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+	},
+	"segments": {
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+	"notifications": {
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	},
+	"campaigns": {
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "category", Value: 1}}},
+		{Keys: bson.D{{Key: "is_active", Value: 1}, {Key: "start_date", Value: 1}, {Key: "end_date", Value: 1}}},
+		{Keys: bson.D{{Key: "segment_ids", Value: 1}}},
+	},
+	"carts": {
+		{Keys: bson.D{{Key: "user._id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	},
+	"cart_items": {
+		{Keys: bson.D{{Key: "cart_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	},
+	"discount_rules": {
+		{Keys: bson.D{{Key: "campaign_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	},
+}
+
+func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, models := range collectionIndexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
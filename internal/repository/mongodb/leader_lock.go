@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type leaderLock struct {
+	coll *mongo.Collection
+}
+
+func NewLeaderLock(db *mongo.Database) domain.LeaderLock {
+	return &leaderLock{coll: db.Collection("locks")}
+}
+
+// Acquire upserts the lock document if it is free, expired or already ours.
+// When another holder owns a live lease the upsert collides on _id, which is
+// how we learn we are not the leader.
+func (l *leaderLock) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	_, err := l.coll.UpdateOne(
+		ctx,
+		bson.M{
+			"_id": name,
+			"$or": bson.A{
+				bson.M{"holder": holder},
+				bson.M{"expires_at": bson.M{"$lte": now}},
+			},
+		},
+		bson.M{"$set": bson.M{"holder": holder, "expires_at": now.Add(ttl)}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (l *leaderLock) Release(ctx context.Context, name, holder string) error {
+	_, err := l.coll.DeleteOne(ctx, bson.M{"_id": name, "holder": holder})
+	return err
+}
@@ -0,0 +1,208 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+	"play-to-win-api/internal/domain"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type listSpec struct {
+	sortable    map[string]string
+	defaultSort domain.SortField
+}
+
+type pageCursor struct {
+	Value bson.RawValue      `bson:"v"`
+	ID    primitive.ObjectID `bson:"id"`
+}
+
+// listPage runs a filtered, sorted and paginated query. Extra stages such as
+// $lookup run after $limit so they only touch the documents being returned.
+func listPage[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, q domain.ListQuery, spec listSpec, stages ...bson.M) ([]T, *domain.Pagination, error) {
+	q.Normalize()
+
+	sortFields, err := resolveSort(q.Sort, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if q.UsesCursor() && len(sortFields) > 1 {
+		return nil, nil, domain.ErrInvalidListQuery
+	}
+
+	match := bson.M{}
+	for key, value := range filter {
+		match[key] = value
+	}
+
+	pagination := &domain.Pagination{Limit: q.Limit}
+	if !q.UsesCursor() {
+		total, err := coll.CountDocuments(ctx, match)
+		if err != nil {
+			return nil, nil, err
+		}
+		pagination.Page = q.Page
+		pagination.Total = total
+		pagination.TotalPages = int(math.Ceil(float64(total) / float64(q.Limit)))
+	} else {
+		condition, err := cursorCondition(q.Cursor, sortFields[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		match = bson.M{"$and": bson.A{match, condition}}
+	}
+
+	sortStage := bson.D{}
+	for _, field := range sortFields {
+		sortStage = append(sortStage, bson.E{Key: field.Field, Value: sortDirection(field)})
+	}
+	sortStage = append(sortStage, bson.E{Key: "_id", Value: sortDirection(sortFields[len(sortFields)-1])})
+
+	pipeline := []bson.M{{"$match": match}, {"$sort": sortStage}}
+	if !q.UsesCursor() {
+		pipeline = append(pipeline, bson.M{"$skip": int64(q.Page-1) * int64(q.Limit)})
+	}
+	pipeline = append(pipeline, bson.M{"$limit": q.Limit + 1})
+	pipeline = append(pipeline, stages...)
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var raws []bson.Raw
+	if err := cursor.All(ctx, &raws); err != nil {
+		return nil, nil, err
+	}
+
+	if len(raws) > q.Limit {
+		raws = raws[:q.Limit]
+		pagination.HasNext = true
+	}
+
+	items := make([]T, 0, len(raws))
+	for _, raw := range raws {
+		var item T
+		if err := bson.Unmarshal(raw, &item); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, item)
+	}
+
+	if pagination.HasNext && len(sortFields) == 1 {
+		next, err := encodeCursor(raws[len(raws)-1], sortFields[0].Field)
+		if err != nil {
+			return nil, nil, err
+		}
+		pagination.NextCursor = next
+	}
+
+	return items, pagination, nil
+}
+
+func resolveSort(requested []domain.SortField, spec listSpec) ([]domain.SortField, error) {
+	if len(requested) == 0 {
+		requested = []domain.SortField{spec.defaultSort}
+	}
+
+	resolved := make([]domain.SortField, 0, len(requested))
+	for _, field := range requested {
+		column, ok := spec.sortable[field.Field]
+		if !ok {
+			return nil, domain.ErrInvalidSortField
+		}
+		resolved = append(resolved, domain.SortField{Field: column, Desc: field.Desc})
+	}
+	return resolved, nil
+}
+
+func sortDirection(field domain.SortField) int {
+	if field.Desc {
+		return -1
+	}
+	return 1
+}
+
+func encodeCursor(raw bson.Raw, field string) (string, error) {
+	value, err := raw.LookupErr(strings.Split(field, ".")...)
+	if err != nil {
+		value = bson.RawValue{Type: bson.TypeNull}
+	}
+	id, _ := raw.Lookup("_id").ObjectIDOK()
+
+	data, err := bson.Marshal(pageCursor{Value: value, ID: id})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func cursorCondition(encoded string, field domain.SortField) (bson.M, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var cursor pageCursor
+	if err := bson.Unmarshal(data, &cursor); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	op := "$gt"
+	if field.Desc {
+		op = "$lt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field.Field: bson.M{op: cursor.Value}},
+		bson.M{field.Field: cursor.Value, "_id": bson.M{op: cursor.ID}},
+	}}, nil
+}
+
+func applyCreatedRange(filter bson.M, f domain.ListFilter) {
+	applyRange(filter, "created_at", f.CreatedFrom, f.CreatedTo)
+}
+
+func applyPriceRange(filter bson.M, field string, f domain.ListFilter) {
+	applyRange(filter, field, f.MinPrice, f.MaxPrice)
+}
+
+func applyRange[V any](filter bson.M, field string, from, to *V) {
+	condition := bson.M{}
+	if from != nil {
+		condition["$gte"] = *from
+	}
+	if to != nil {
+		condition["$lte"] = *to
+	}
+	if len(condition) > 0 {
+		filter[field] = condition
+	}
+}
+
+// eachDocument streams every matching document through fn without loading the
+// whole result set into memory.
+func eachDocument[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, sort bson.D, fn func(*T) error) error {
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(sort))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"context"
+	"os"
+	mongoClient "play-to-win-api/pkg/mongodb"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// testDatabase returns a throwaway, indexed database on the server in
+// MONGODB_TEST_URI, skipping the test when it isn't set.
+func testDatabase(t *testing.T) *mongo.Database {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI is not set")
+	}
+
+	ctx := context.Background()
+	db, err := mongoClient.NewClient(ctx, uri, "repository_test_"+primitive.NewObjectID().Hex())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Drop(context.Background()) })
+
+	require.NoError(t, EnsureIndexes(ctx, db))
+	return db
+}
@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type notificationRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewNotificationRepository(db *mongo.Database) domain.NotificationRepository {
+	return &notificationRepository{
+		db:   db,
+		coll: db.Collection("notifications"),
+	}
+}
+
+func (r *notificationRepository) CreateMany(ctx context.Context, notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(notifications))
+	for i := range notifications {
+		notifications[i].CreatedAt = time.Now()
+		docs[i] = notifications[i]
+	}
+	_, err := r.coll.InsertMany(ctx, docs)
+	return err
+}
+
+var notificationListSpec = listSpec{
+	sortable: map[string]string{
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *notificationRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, q domain.ListQuery) ([]domain.Notification, *domain.Pagination, error) {
+	filter := bson.M{"user_id": userID}
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Type != "" {
+		filter["type"] = q.Filter.Type
+	}
+
+	return listPage[domain.Notification](ctx, r.coll, filter, q, notificationListSpec)
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id primitive.ObjectID) error {
+	result, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "user_id": userID},
+		bson.M{"$set": bson.M{"read_at": time.Now()}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
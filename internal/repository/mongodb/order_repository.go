@@ -0,0 +1,191 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type orderRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewOrderRepository(db *mongo.Database) domain.OrderRepository {
+	return &orderRepository{
+		db:   db,
+		coll: db.Collection("orders"),
+	}
+}
+
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	order.CreatedAt = time.Now()
+	if order.ID.IsZero() {
+		order.ID = primitive.NewObjectID()
+	}
+	_, err := r.coll.InsertOne(ctx, order)
+	return err
+}
+
+func (r *orderRepository) FindByID(ctx context.Context, userID, id primitive.ObjectID) (*domain.Order, error) {
+	var order domain.Order
+	err := r.coll.FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&order)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+var orderListSpec = listSpec{
+	sortable: map[string]string{
+		"created_at": "created_at",
+		"total":      "total",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *orderRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, q domain.ListQuery) ([]domain.Order, *domain.Pagination, error) {
+	filter := bson.M{"user_id": userID}
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Status != "" {
+		filter["status"] = q.Filter.Status
+	}
+
+	return listPage[domain.Order](ctx, r.coll, filter, q, orderListSpec)
+}
+
+func (r *orderRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	return r.coll.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (r *orderRepository) HasPurchased(ctx context.Context, userID, productID primitive.ObjectID) (bool, error) {
+	count, err := r.coll.CountDocuments(ctx, bson.M{"user_id": userID, "items.product_id": productID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *orderRepository) EachBetween(ctx context.Context, from, to time.Time, fn func(*domain.Order) error) error {
+	filter := bson.M{"created_at": bson.M{"$gte": from, "$lte": to}}
+	return eachDocument(ctx, r.coll, filter, bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}, fn)
+}
+
+// CampaignStats tags every order in range with whether it redeemed the
+// campaign and how much the campaign took off, then computes each figure in
+// its own $facet branch so the orders are only scanned once.
+func (r *orderRepository) CampaignStats(ctx context.Context, campaignID primitive.ObjectID, q domain.CampaignStatsQuery) (*domain.CampaignStats, error) {
+	match := bson.M{"currency": bson.M{"$in": bson.A{nil, domain.BaseCurrency}}}
+	applyRange(match, "created_at", q.From, q.To)
+
+	redeemed := bson.M{"redeemed": true}
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$set": bson.M{
+			"redeemed": bson.M{"$in": bson.A{campaignID, bson.M{"$ifNull": bson.A{"$discounts.campaign_id", bson.A{}}}}},
+			"campaign_discount": bson.M{"$sum": bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$discounts", bson.A{}}},
+					"cond":  bson.M{"$eq": bson.A{"$$this.campaign_id", campaignID}},
+				}},
+				"in": "$$this.amount",
+			}}},
+		}},
+		{"$facet": bson.M{
+			"with": []bson.M{
+				{"$match": redeemed},
+				{"$group": bson.M{
+					"_id":      nil,
+					"orders":   bson.M{"$sum": 1},
+					"discount": bson.M{"$sum": "$campaign_discount"},
+					"gross":    bson.M{"$sum": "$subtotal"},
+					"net":      bson.M{"$sum": "$total"},
+					"average":  bson.M{"$avg": "$total"},
+				}},
+			},
+			"without": []bson.M{
+				{"$match": bson.M{"redeemed": false}},
+				{"$group": bson.M{
+					"_id":     nil,
+					"orders":  bson.M{"$sum": 1},
+					"average": bson.M{"$avg": "$total"},
+				}},
+			},
+			"customers": []bson.M{
+				{"$match": redeemed},
+				{"$group": bson.M{"_id": "$user_id"}},
+				{"$count": "count"},
+			},
+			"top_products": []bson.M{
+				{"$match": redeemed},
+				{"$unwind": "$items"},
+				{"$group": bson.M{
+					"_id":          "$items.product_id",
+					"product_name": bson.M{"$first": "$items.product_name"},
+					"quantity":     bson.M{"$sum": "$items.quantity"},
+					"revenue":      bson.M{"$sum": "$items.total_price"},
+				}},
+				{"$sort": bson.D{{Key: "revenue", Value: -1}, {Key: "_id", Value: 1}}},
+				{"$limit": q.TopProducts},
+			},
+		}},
+	}
+
+	cursor, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		With []struct {
+			Orders   int64        `bson:"orders"`
+			Discount domain.Money `bson:"discount"`
+			Gross    domain.Money `bson:"gross"`
+			Net      domain.Money `bson:"net"`
+			Average  domain.Money `bson:"average"`
+		} `bson:"with"`
+		Without []struct {
+			Orders  int64        `bson:"orders"`
+			Average domain.Money `bson:"average"`
+		} `bson:"without"`
+		Customers []struct {
+			Count int64 `bson:"count"`
+		} `bson:"customers"`
+		TopProducts []domain.CampaignProductStats `bson:"top_products"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	stats := &domain.CampaignStats{CampaignID: campaignID, TopProducts: []domain.CampaignProductStats{}}
+	if len(results) == 0 {
+		return stats, nil
+	}
+	result := results[0]
+	if len(result.With) > 0 {
+		with := result.With[0]
+		stats.Redemptions = with.Orders
+		stats.DiscountGiven = with.Discount
+		stats.GrossRevenue = with.Gross
+		stats.NetRevenue = with.Net
+		stats.AverageOrderValue = with.Average
+	}
+	if len(result.Without) > 0 {
+		stats.OrdersWithout = result.Without[0].Orders
+		stats.AverageOrderValueWithout = result.Without[0].Average
+	}
+	if len(result.Customers) > 0 {
+		stats.Customers = result.Customers[0].Count
+	}
+	if result.TopProducts != nil {
+		stats.TopProducts = result.TopProducts
+	}
+	return stats, nil
+}
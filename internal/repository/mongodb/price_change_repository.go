@@ -0,0 +1,124 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type priceChangeRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewPriceChangeRepository(db *mongo.Database) domain.PriceChangeRepository {
+	return &priceChangeRepository{
+		db:   db,
+		coll: db.Collection("price_changes"),
+	}
+}
+
+func (r *priceChangeRepository) Create(ctx context.Context, change *domain.PriceChange) error {
+	change.CreatedAt = time.Now()
+	result, err := r.coll.InsertOne(ctx, change)
+	if err != nil {
+		return err
+	}
+	change.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *priceChangeRepository) FindByID(ctx context.Context, id string) (*domain.PriceChange, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidPriceChangeID
+	}
+
+	var change domain.PriceChange
+	err = r.coll.FindOne(ctx, bson.M{"_id": objectID}).Decode(&change)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrPriceChangeNotFound
+	}
+	return &change, err
+}
+
+var priceChangeListSpec = listSpec{
+	sortable: map[string]string{
+		"effective_from": "effective_from",
+		"created_at":     "created_at",
+		"price":          "price",
+	},
+	defaultSort: domain.SortField{Field: "effective_from", Desc: true},
+}
+
+func (r *priceChangeRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID, q domain.ListQuery) ([]domain.PriceChange, *domain.Pagination, error) {
+	filter := bson.M{"product_id": productID}
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Status != "" {
+		filter["status"] = q.Filter.Status
+	}
+
+	return listPage[domain.PriceChange](ctx, r.coll, filter, q, priceChangeListSpec)
+}
+
+func (r *priceChangeRepository) FindAppliedSince(ctx context.Context, productID primitive.ObjectID, since time.Time) ([]domain.PriceChange, error) {
+	filter := bson.M{
+		"product_id": productID,
+		"status":     domain.PriceChangeApplied,
+		"applied_at": bson.M{"$gt": since},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "applied_at", Value: 1}})
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var changes []domain.PriceChange
+	err = cursor.All(ctx, &changes)
+	return changes, err
+}
+
+func (r *priceChangeRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]domain.PriceChange, error) {
+	filter := bson.M{
+		"status":         domain.PriceChangeScheduled,
+		"effective_from": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "effective_from", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var changes []domain.PriceChange
+	err = cursor.All(ctx, &changes)
+	return changes, err
+}
+
+func (r *priceChangeRepository) MarkStatus(ctx context.Context, id primitive.ObjectID, status domain.PriceChangeStatus, at time.Time) (bool, error) {
+	set := bson.M{"status": status}
+	if status == domain.PriceChangeApplied {
+		set["applied_at"] = at
+	}
+
+	result, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": domain.PriceChangeScheduled},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *priceChangeRepository) UpdatePreviousPrice(ctx context.Context, id primitive.ObjectID, previous domain.Money) error {
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"previous_price": previous}})
+	return err
+}
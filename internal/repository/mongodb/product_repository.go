@@ -5,8 +5,10 @@ import (
 	"play-to-win-api/internal/domain"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type productRepository struct {
@@ -38,35 +40,257 @@ func (r *productRepository) FindByID(ctx context.Context, id string) (*domain.Pr
 		return nil, err
 	}
 	var product domain.Product
-	err = r.coll.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&product)
+	err = r.coll.FindOne(ctx, live(bson.M{"_id": objectID})).Decode(&product)
 	return &product, err
 }
 
-func (r *productRepository) FindAll(ctx context.Context) ([]domain.Product, error) {
-	cursor, err := r.coll.Find(ctx, primitive.M{})
-	if err != nil {
-		return nil, err
+func (r *productRepository) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.coll.FindOne(ctx, live(bson.M{"sku": sku})).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrProductNotFound
+	}
+	return &product, err
+}
+
+func (r *productRepository) FindByName(ctx context.Context, name string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.coll.FindOne(ctx, live(bson.M{"name": name})).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrProductNotFound
+	}
+	return &product, err
+}
+
+func (r *productRepository) FindBySlug(ctx context.Context, slug string) (*domain.Product, error) {
+	return findBySlug[domain.Product](ctx, r.coll, slug, domain.ErrProductNotFound)
+}
+
+func (r *productRepository) SlugTaken(ctx context.Context, slug string, exclude primitive.ObjectID) (bool, error) {
+	return slugTaken(ctx, r.coll, slug, exclude)
+}
+
+func (r *productRepository) FindByVariantSKU(ctx context.Context, sku string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.coll.FindOne(ctx, live(bson.M{"variants.sku": sku})).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrProductNotFound
+	}
+	return &product, err
+}
+
+var productListSpec = listSpec{
+	sortable: map[string]string{
+		"name":       "name",
+		"price":      "price",
+		"stock":      "stock",
+		"sold":       "sold",
+		"rating":     "rating_average",
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *productRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	filter := live(bson.M{})
+	applyPriceRange(filter, "price", q.Filter)
+	applyCreatedRange(filter, q.Filter)
+	if len(q.Filter.CategoryIDs) > 0 {
+		filter["category_id"] = bson.M{"$in": q.Filter.CategoryIDs}
+	}
+	if q.Filter.InStock != nil {
+		if *q.Filter.InStock {
+			filter["stock"] = bson.M{"$gt": 0}
+		} else {
+			filter["stock"] = bson.M{"$lte": 0}
+		}
 	}
-	var products []domain.Product
-	err = cursor.All(ctx, &products)
-	return products, err
+
+	return listPage[domain.Product](ctx, r.coll, filter, q, productListSpec)
 }
 
 func (r *productRepository) Update(ctx context.Context, p *domain.Product) error {
 	p.UpdatedAt = time.Now()
 	_, err := r.coll.UpdateOne(
 		ctx,
-		primitive.M{"_id": p.ID},
+		live(bson.M{"_id": p.ID}),
 		primitive.M{"$set": p},
 	)
 	return err
 }
 
+func (r *productRepository) UpdateVariants(ctx context.Context, id primitive.ObjectID, variants []domain.ProductVariant) error {
+	stock := 0
+	for _, variant := range variants {
+		stock += variant.Stock
+	}
+
+	_, err := r.coll.UpdateOne(
+		ctx,
+		live(bson.M{"_id": id}),
+		bson.M{"$set": bson.M{
+			"variants":   variants,
+			"stock":      stock,
+			"updated_at": time.Now(),
+		}},
+	)
+	return err
+}
+
+func (r *productRepository) UpdateImages(ctx context.Context, id primitive.ObjectID, images []domain.ProductImage) error {
+	update := bson.M{
+		"images":     images,
+		"updated_at": time.Now(),
+	}
+	if len(images) > 0 {
+		update["image"] = images[0].URL
+	}
+
+	_, err := r.coll.UpdateOne(ctx, live(bson.M{"_id": id}), bson.M{"$set": update})
+	return err
+}
+
+// AdjustRating applies a review change to the denormalised rating in a single
+// atomic update so concurrent reviews cannot lose each other's counts.
+func (r *productRepository) AdjustRating(ctx context.Context, id primitive.ObjectID, countDelta, sumDelta int) error {
+	_, err := r.coll.UpdateOne(ctx, live(bson.M{"_id": id}), mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"rating_count": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating_count", 0}}, countDelta}},
+			"rating_sum":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating_sum", 0}}, sumDelta}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"rating_average": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$rating_count", 0}},
+				bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$rating_sum", "$rating_count"}}, 2}},
+				0,
+			}},
+		}}},
+	})
+	return err
+}
+
+func (r *productRepository) UpdatePrice(ctx context.Context, id primitive.ObjectID, price domain.Money, wasPrice *domain.Money) error {
+	update := bson.M{"$set": bson.M{"price": price, "updated_at": time.Now()}}
+	if wasPrice != nil {
+		update["$set"].(bson.M)["was_price"] = *wasPrice
+	} else {
+		update["$unset"] = bson.M{"was_price": ""}
+	}
+
+	_, err := r.coll.UpdateOne(ctx, live(bson.M{"_id": id}), update)
+	return err
+}
+
+// AdjustStock moves stock by delta atomically, refusing to let it drop below
+// zero. Variant adjustments keep the product total in step.
+func (r *productRepository) AdjustStock(ctx context.Context, id primitive.ObjectID, variantID *primitive.ObjectID, delta int) (*domain.Product, error) {
+	filter := live(bson.M{"_id": id})
+	inc := bson.M{"stock": delta}
+	if variantID != nil {
+		match := bson.M{"_id": *variantID}
+		if delta < 0 {
+			match["stock"] = bson.M{"$gte": -delta}
+		}
+		filter["variants"] = bson.M{"$elemMatch": match}
+		inc["variants.$.stock"] = delta
+	} else if delta < 0 {
+		filter["stock"] = bson.M{"$gte": -delta}
+	}
+
+	var product domain.Product
+	err := r.coll.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$inc": inc, "$set": bson.M{"updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrInsufficientStock
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) SetStock(ctx context.Context, id primitive.ObjectID, variantID *primitive.ObjectID, observed, level int) (*domain.Product, error) {
+	filter := live(bson.M{"_id": id})
+	inc := bson.M{"stock": level - observed}
+	if variantID != nil {
+		filter["variants"] = bson.M{"$elemMatch": bson.M{"_id": *variantID, "stock": observed}}
+		inc["variants.$.stock"] = level - observed
+	} else {
+		filter["stock"] = observed
+	}
+
+	var product domain.Product
+	err := r.coll.FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$inc": inc, "$set": bson.M{"updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrStockChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+var lowStockListSpec = listSpec{
+	sortable: map[string]string{
+		"stock":      "stock",
+		"name":       "name",
+		"updated_at": "updated_at",
+	},
+	defaultSort: domain.SortField{Field: "stock"},
+}
+
+func (r *productRepository) FindLowStock(ctx context.Context, defaultThreshold int, q domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	filter := live(bson.M{
+		"$expr": bson.M{"$lte": bson.A{"$stock", bson.M{"$ifNull": bson.A{"$low_stock_threshold", defaultThreshold}}}},
+	})
+	if len(q.Filter.CategoryIDs) > 0 {
+		filter["category_id"] = bson.M{"$in": q.Filter.CategoryIDs}
+	}
+
+	return listPage[domain.Product](ctx, r.coll, filter, q, lowStockListSpec)
+}
+
 func (r *productRepository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
-	_, err = r.coll.DeleteOne(ctx, primitive.M{"_id": objectID})
-	return err
+	return softDelete(ctx, r.coll, objectID, domain.ErrProductNotFound)
+}
+
+func (r *productRepository) Restore(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidProductID
+	}
+	return restoreArchived(ctx, r.coll, objectID, domain.ErrProductNotFound)
+}
+
+var archivedListSpec = listSpec{
+	sortable: map[string]string{
+		"name":       "name",
+		"deleted_at": "deleted_at",
+	},
+	defaultSort: domain.SortField{Field: "deleted_at", Desc: true},
+}
+
+func (r *productRepository) FindArchived(ctx context.Context, q domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	return listPage[domain.Product](ctx, r.coll, archived(bson.M{}), q, archivedListSpec)
+}
+
+func (r *productRepository) PurgeArchived(ctx context.Context, before time.Time) (int64, error) {
+	return purgeArchived(ctx, r.coll, before)
+}
+
+func (r *productRepository) Each(ctx context.Context, fn func(*domain.Product) error) error {
+	return eachDocument(ctx, r.coll, live(bson.M{}), bson.D{{Key: "_id", Value: 1}}, fn)
 }
@@ -0,0 +1,40 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProductRepository_LeavesArchivedProductsAlone(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+	repo := NewProductRepository(db)
+
+	product := &domain.Product{
+		Name: "Mug", Description: "Mug", Content: "mug", Price: 150_00, Image: "mug.jpg",
+		Variants: []domain.ProductVariant{{ID: primitive.NewObjectID(), SKU: "MUG-RED", Stock: 3}},
+	}
+	require.NoError(t, repo.Create(ctx, product))
+	require.NoError(t, repo.Delete(ctx, product.ID.Hex()))
+
+	_, err := repo.FindByVariantSKU(ctx, "MUG-RED")
+	assert.Equal(t, domain.ErrProductNotFound, err)
+
+	require.NoError(t, repo.UpdatePrice(ctx, product.ID, 99_00, nil))
+	require.NoError(t, repo.UpdateVariants(ctx, product.ID, nil))
+	require.NoError(t, repo.UpdateImages(ctx, product.ID, []domain.ProductImage{{ID: primitive.NewObjectID()}}))
+	require.NoError(t, repo.AdjustRating(ctx, product.ID, 1, 5))
+
+	var stored domain.Product
+	require.NoError(t, db.Collection("products").FindOne(ctx, bson.M{"_id": product.ID}).Decode(&stored))
+	assert.Equal(t, domain.Money(150_00), stored.Price)
+	assert.Len(t, stored.Variants, 1)
+	assert.Empty(t, stored.Images)
+	assert.Zero(t, stored.RatingCount)
+}
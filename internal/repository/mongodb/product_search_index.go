@@ -0,0 +1,209 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/textsearch"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type productSearchIndex struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+// NewProductSearchIndex searches the products collection through its text
+// index, which MongoDB keeps up to date on every write.
+func NewProductSearchIndex(db *mongo.Database) domain.SearchIndex {
+	return &productSearchIndex{
+		db:   db,
+		coll: db.Collection("products"),
+	}
+}
+
+type searchFacetResult struct {
+	Hits       []domain.ProductSearchHit `bson:"hits"`
+	Total      []struct{ Count int64 }   `bson:"total"`
+	Categories []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Count int64              `bson:"count"`
+	} `bson:"categories"`
+	Prices []struct {
+		ID    bson.RawValue `bson:"_id"`
+		Count int64         `bson:"count"`
+	} `bson:"prices"`
+}
+
+func (r *productSearchIndex) Index(ctx context.Context, product *domain.Product) error {
+	return nil
+}
+
+func (r *productSearchIndex) Remove(ctx context.Context, id string) error {
+	return nil
+}
+
+func (r *productSearchIndex) Search(ctx context.Context, query domain.ProductSearchQuery) (*domain.ProductSearchResult, error) {
+	terms := textsearch.Tokenize(query.Query)
+	if len(terms) == 0 {
+		return nil, domain.ErrEmptySearchQuery
+	}
+
+	result, err := r.search(ctx, query, allTerms(terms), true)
+	if err != nil || result.Total > 0 {
+		return result, err
+	}
+
+	fuzzy := bson.A{}
+	for _, term := range terms {
+		pattern := primitive.Regex{Pattern: textsearch.FuzzyPattern(term), Options: "i"}
+		fuzzy = append(fuzzy, bson.M{"$or": bson.A{
+			bson.M{"name": pattern},
+			bson.M{"description": pattern},
+			bson.M{"content": pattern},
+		}})
+	}
+
+	result, err = r.search(ctx, query, bson.M{"$and": fuzzy}, false)
+	if err != nil {
+		return nil, err
+	}
+	result.Fuzzy = true
+	return result, nil
+}
+
+// allTerms quotes each term, because $text otherwise matches products that
+// contain any of them and a search has to match all of them.
+func allTerms(terms []string) bson.M {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + term + `"`
+	}
+	return bson.M{"$text": bson.M{"$search": strings.Join(quoted, " ")}}
+}
+
+func (r *productSearchIndex) search(ctx context.Context, query domain.ProductSearchQuery, match bson.M, textScore bool) (*domain.ProductSearchResult, error) {
+	live(match)
+	if len(query.CategoryIDs) > 0 {
+		match["category_id"] = bson.M{"$in": query.CategoryIDs}
+	}
+	priceRange := bson.M{}
+	if query.MinPrice != nil {
+		priceRange["$gte"] = *query.MinPrice
+	}
+	if query.MaxPrice != nil {
+		priceRange["$lte"] = *query.MaxPrice
+	}
+	if len(priceRange) > 0 {
+		match["price"] = priceRange
+	}
+
+	hits := []bson.M{}
+	if textScore {
+		hits = append(hits,
+			bson.M{"$addFields": bson.M{"score": bson.M{"$meta": "textScore"}}},
+			bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "sold", Value: -1}}},
+		)
+	} else {
+		hits = append(hits, bson.M{"$sort": bson.D{{Key: "sold", Value: -1}, {Key: "_id", Value: 1}}})
+	}
+	hits = append(hits, bson.M{"$skip": query.Offset})
+	if query.Limit > 0 {
+		hits = append(hits, bson.M{"$limit": query.Limit})
+	}
+
+	boundaries := bson.A{}
+	for _, boundary := range domain.SearchPriceBoundaries {
+		boundaries = append(boundaries, boundary)
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$facet": bson.M{
+			"hits":       hits,
+			"total":      []bson.M{{"$count": "count"}},
+			"categories": []bson.M{{"$group": bson.M{"_id": "$category_id", "count": bson.M{"$sum": 1}}}, {"$sort": bson.M{"count": -1}}},
+			"prices": []bson.M{{"$bucket": bson.M{
+				"groupBy":    "$price",
+				"boundaries": append(boundaries, domain.SearchPriceBoundaries[len(domain.SearchPriceBoundaries)-1]*1e6),
+				"default":    "other",
+			}}},
+		}},
+	}
+
+	cursor, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []searchFacetResult
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+
+	result := &domain.ProductSearchResult{
+		Hits: []domain.ProductSearchHit{},
+		Facets: domain.SearchFacets{
+			Categories:   []domain.FacetCount{},
+			PriceBuckets: domain.NewPriceBucketCounts(),
+		},
+	}
+	if len(facets) == 0 {
+		return result, nil
+	}
+
+	facet := facets[0]
+	result.Hits = append(result.Hits, facet.Hits...)
+	if len(facet.Total) > 0 {
+		result.Total = facet.Total[0].Count
+	}
+	for _, category := range facet.Categories {
+		if category.ID.IsZero() {
+			continue
+		}
+		result.Facets.Categories = append(result.Facets.Categories, domain.FacetCount{Value: category.ID.Hex(), Count: category.Count})
+	}
+	for _, price := range facet.Prices {
+		var lower domain.Money
+		if err := price.ID.Unmarshal(&lower); err == nil {
+			result.Facets.PriceBuckets[domain.PriceBucketIndex(lower)].Count += price.Count
+		}
+	}
+	return result, nil
+}
+
+func (r *productSearchIndex) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, domain.ErrEmptySearchQuery
+	}
+
+	filter := live(bson.M{"name": primitive.Regex{Pattern: `(^|\s)` + regexp.QuoteMeta(prefix), Options: "i"}})
+	opts := options.Find().
+		SetProjection(bson.M{"name": 1}).
+		SetSort(bson.D{{Key: "sold", Value: -1}, {Key: "name", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var products []domain.Product
+	if err := cursor.All(ctx, &products); err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]string, 0, len(products))
+	for _, product := range products {
+		suggestions = append(suggestions, product.Name)
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,27 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/internal/repository/searchtest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestAllTerms(t *testing.T) {
+	assert.Equal(t, bson.M{"$text": bson.M{"$search": `"linen" "shirt"`}}, allTerms([]string{"linen", "shirt"}))
+}
+
+func TestProductSearchIndex_MatchesEveryTerm(t *testing.T) {
+	searchtest.RunMultiTerm(t, func(t *testing.T, products []domain.Product) domain.SearchIndex {
+		db := testDatabase(t)
+		productRepo := NewProductRepository(db)
+		for i := range products {
+			require.NoError(t, productRepo.Create(context.Background(), &products[i]))
+		}
+		return NewProductSearchIndex(db)
+	})
+}
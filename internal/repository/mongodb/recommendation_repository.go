@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const recommendationsCollection = "product_recommendations"
+
+type recommendationRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewRecommendationRepository(db *mongo.Database) domain.RecommendationRepository {
+	return &recommendationRepository{
+		db:   db,
+		coll: db.Collection(recommendationsCollection),
+	}
+}
+
+// Rebuild counts, for every pair of products, how many baskets contain both.
+// A basket is an open cart or a placed order; checkout empties the cart, so
+// orders are where completed purchases are counted. $out swaps the result in
+// atomically so readers never see a partial table.
+func (r *recommendationRepository) Rebuild(ctx context.Context, settings domain.RecommendationSettings) (int64, error) {
+	pipeline := []bson.M{
+		{"$group": bson.M{"_id": "$cart_id", "products": bson.M{"$addToSet": "$product_id"}}},
+		{"$unionWith": bson.M{
+			"coll": "orders",
+			"pipeline": bson.A{
+				bson.M{"$project": bson.M{"products": bson.M{"$setUnion": bson.A{"$items.product_id", bson.A{}}}}},
+			},
+		}},
+		{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+			bson.M{"$gte": bson.A{bson.M{"$size": "$products"}, 2}},
+			bson.M{"$lte": bson.A{bson.M{"$size": "$products"}, settings.MaxCartSize}},
+		}}}},
+		{"$project": bson.M{"product_id": "$products", "related_id": "$products"}},
+		{"$unwind": "$product_id"},
+		{"$unwind": "$related_id"},
+		{"$match": bson.M{"$expr": bson.M{"$ne": bson.A{"$product_id", "$related_id"}}}},
+		{"$group": bson.M{
+			"_id":   bson.M{"product_id": "$product_id", "related_id": "$related_id"},
+			"score": bson.M{"$sum": 1},
+		}},
+		{"$match": bson.M{"score": bson.M{"$gte": settings.MinSupport}}},
+		{"$sort": bson.D{{Key: "_id.product_id", Value: 1}, {Key: "score", Value: -1}, {Key: "_id.related_id", Value: 1}}},
+		{"$group": bson.M{
+			"_id":     "$_id.product_id",
+			"related": bson.M{"$push": bson.M{"related_id": "$_id.related_id", "score": "$score"}},
+		}},
+		{"$project": bson.M{"related": bson.M{"$slice": bson.A{"$related", settings.PerProduct}}}},
+		{"$unwind": "$related"},
+		{"$project": bson.M{
+			"_id":         0,
+			"product_id":  "$_id",
+			"related_id":  "$related.related_id",
+			"score":       "$related.score",
+			"computed_at": time.Now(),
+		}},
+		{"$out": recommendationsCollection},
+	}
+
+	cursor, err := r.db.Collection("cart_items").Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return 0, err
+	}
+	if err := cursor.Close(ctx); err != nil {
+		return 0, err
+	}
+	return r.coll.EstimatedDocumentCount(ctx)
+}
+
+func (r *recommendationRepository) FindRelated(ctx context.Context, productIDs []primitive.ObjectID, limit int) ([]domain.Product, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"product_id": bson.M{"$in": productIDs},
+			"related_id": bson.M{"$nin": productIDs},
+		}},
+		{"$group": bson.M{"_id": "$related_id", "score": bson.M{"$sum": "$score"}}},
+		{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$lookup": bson.M{
+			"from":         "products",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "product",
+		}},
+		{"$unwind": "$product"},
+		{"$match": bson.M{
+			"product.deleted_at": nil,
+			"product.stock":      bson.M{"$gt": 0},
+		}},
+		{"$limit": limit},
+		{"$replaceRoot": bson.M{"newRoot": "$product"}},
+	}
+
+	cursor, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	products := []domain.Product{}
+	err = cursor.All(ctx, &products)
+	return products, err
+}
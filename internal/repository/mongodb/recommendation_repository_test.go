@@ -0,0 +1,39 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRecommendationRepository_RebuildCountsCheckedOutBaskets(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	productRepo := NewProductRepository(db)
+	mug := &domain.Product{Name: "Mug", Description: "Mug", Content: "mug", Price: 150_00, Image: "mug.jpg", Stock: 5}
+	coaster := &domain.Product{Name: "Coaster", Description: "Coaster", Content: "cork", Price: 50_00, Image: "coaster.jpg", Stock: 5}
+	require.NoError(t, productRepo.Create(ctx, mug))
+	require.NoError(t, productRepo.Create(ctx, coaster))
+
+	// Checkout deletes the cart items, so the order is all that's left of
+	// the basket.
+	require.NoError(t, NewOrderRepository(db).Create(ctx, &domain.Order{
+		UserID: primitive.NewObjectID(),
+		Items:  []domain.OrderItem{{ProductID: mug.ID, Quantity: 1}, {ProductID: coaster.ID, Quantity: 2}},
+	}))
+
+	repo := NewRecommendationRepository(db)
+	pairs, err := repo.Rebuild(ctx, domain.RecommendationSettings{MinSupport: 1, PerProduct: 10, MaxCartSize: 50})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, pairs)
+
+	related, err := repo.FindRelated(ctx, []primitive.ObjectID{mug.ID}, 5)
+	require.NoError(t, err)
+	require.Len(t, related, 1)
+	assert.Equal(t, coaster.ID, related[0].ID)
+}
@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type reviewRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewReviewRepository(db *mongo.Database) domain.ReviewRepository {
+	return &reviewRepository{
+		db:   db,
+		coll: db.Collection("reviews"),
+	}
+}
+
+func (r *reviewRepository) Create(ctx context.Context, review *domain.Review) error {
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = time.Now()
+	result, err := r.coll.InsertOne(ctx, review)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrReviewAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
+	review.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *reviewRepository) FindByID(ctx context.Context, id string) (*domain.Review, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidReviewID
+	}
+
+	var review domain.Review
+	err = r.coll.FindOne(ctx, bson.M{"_id": objectID}).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrReviewNotFound
+	}
+	return &review, err
+}
+
+var reviewListSpec = listSpec{
+	sortable: map[string]string{
+		"rating":     "rating",
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *reviewRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID, q domain.ListQuery) ([]domain.Review, *domain.Pagination, error) {
+	filter := reviewFilter(q.Filter)
+	filter["product_id"] = productID
+
+	return listPage[domain.Review](ctx, r.coll, filter, q, reviewListSpec)
+}
+
+func (r *reviewRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.Review, *domain.Pagination, error) {
+	return listPage[domain.Review](ctx, r.coll, reviewFilter(q.Filter), q, reviewListSpec)
+}
+
+func reviewFilter(f domain.ListFilter) bson.M {
+	filter := bson.M{}
+	applyCreatedRange(filter, f)
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	return filter
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *domain.Review) error {
+	review.UpdatedAt = time.Now()
+	_, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": review.ID},
+		bson.M{"$set": review},
+	)
+	return err
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidReviewID
+	}
+
+	_, err = r.coll.DeleteOne(ctx, bson.M{"_id": objectID})
+	return err
+}
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type segmentRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewSegmentRepository(db *mongo.Database) domain.SegmentRepository {
+	return &segmentRepository{
+		db:   db,
+		coll: db.Collection("segments"),
+	}
+}
+
+func (r *segmentRepository) Create(ctx context.Context, s *domain.Segment) error {
+	s.CreatedAt = time.Now()
+	s.UpdatedAt = time.Now()
+	result, err := r.coll.InsertOne(ctx, s)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrSegmentExists
+	}
+	if err != nil {
+		return err
+	}
+	s.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *segmentRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Segment, error) {
+	var segment domain.Segment
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&segment)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrSegmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &segment, nil
+}
+
+func (r *segmentRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Segment, error) {
+	cursor, err := r.coll.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	segments := []domain.Segment{}
+	err = cursor.All(ctx, &segments)
+	return segments, err
+}
+
+var segmentListSpec = listSpec{
+	sortable: map[string]string{
+		"name":       "name",
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "name"},
+}
+
+func (r *segmentRepository) FindAll(ctx context.Context, q domain.ListQuery) ([]domain.Segment, *domain.Pagination, error) {
+	filter := bson.M{}
+	applyCreatedRange(filter, q.Filter)
+	return listPage[domain.Segment](ctx, r.coll, filter, q, segmentListSpec)
+}
+
+func (r *segmentRepository) Update(ctx context.Context, s *domain.Segment) error {
+	s.UpdatedAt = time.Now()
+	result, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": s.ID},
+		bson.M{"$set": bson.M{
+			"name":        s.Name,
+			"description": s.Description,
+			"rules":       s.Rules,
+			"updated_at":  s.UpdatedAt,
+		}},
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrSegmentExists
+	}
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrSegmentNotFound
+	}
+	return nil
+}
+
+func (r *segmentRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrSegmentNotFound
+	}
+	return nil
+}
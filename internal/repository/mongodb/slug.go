@@ -0,0 +1,37 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// findBySlug resolves a current slug first and only then falls back to the
+// slugs a document used to have, which callers answer with a redirect.
+func findBySlug[T any](ctx context.Context, coll *mongo.Collection, slug string, notFound error) (*T, error) {
+	for _, field := range []string{"slug", "previous_slugs"} {
+		var doc T
+		err := coll.FindOne(ctx, live(bson.M{field: slug})).Decode(&doc)
+		if err == nil {
+			return &doc, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+	}
+	return nil, notFound
+}
+
+// slugTaken also counts archived documents, since they keep their slugs
+// and may be restored.
+func slugTaken(ctx context.Context, coll *mongo.Collection, slug string, exclude primitive.ObjectID) (bool, error) {
+	filter := bson.M{"$or": bson.A{bson.M{"slug": slug}, bson.M{"previous_slugs": slug}}}
+	if !exclude.IsZero() {
+		filter["_id"] = bson.M{"$ne": exclude}
+	}
+	count, err := coll.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
@@ -0,0 +1,63 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// live restricts filter to documents that have not been soft-deleted.
+func live(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+func archived(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$ne": nil}
+	return filter
+}
+
+func softDelete(ctx context.Context, coll *mongo.Collection, id primitive.ObjectID, notFound error) error {
+	now := time.Now()
+	result, err := coll.UpdateOne(
+		ctx,
+		live(bson.M{"_id": id}),
+		bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return notFound
+	}
+	return nil
+}
+
+func restoreArchived(ctx context.Context, coll *mongo.Collection, id primitive.ObjectID, notFound error) error {
+	result, err := coll.UpdateOne(
+		ctx,
+		archived(bson.M{"_id": id}),
+		bson.M{
+			"$unset": bson.M{"deleted_at": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return notFound
+	}
+	return nil
+}
+
+func purgeArchived(ctx context.Context, coll *mongo.Collection, before time.Time) (int64, error) {
+	result, err := coll.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": before}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type stockMovementRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewStockMovementRepository(db *mongo.Database) domain.StockMovementRepository {
+	return &stockMovementRepository{
+		db:   db,
+		coll: db.Collection("stock_movements"),
+	}
+}
+
+func (r *stockMovementRepository) Create(ctx context.Context, movement *domain.StockMovement) error {
+	movement.CreatedAt = time.Now()
+	result, err := r.coll.InsertOne(ctx, movement)
+	if err != nil {
+		return err
+	}
+	movement.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+var stockMovementListSpec = listSpec{
+	sortable: map[string]string{
+		"created_at": "created_at",
+		"quantity":   "quantity",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *stockMovementRepository) FindByProductID(ctx context.Context, productID primitive.ObjectID, q domain.ListQuery) ([]domain.StockMovement, *domain.Pagination, error) {
+	filter := bson.M{"product_id": productID}
+	applyCreatedRange(filter, q.Filter)
+	if q.Filter.Type != "" {
+		filter["type"] = q.Filter.Type
+	}
+
+	return listPage[domain.StockMovement](ctx, r.coll, filter, q, stockMovementListSpec)
+}
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type taxRegionRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+// NewTaxRegionRepository keeps one document per region, keyed by its code.
+func NewTaxRegionRepository(db *mongo.Database) domain.TaxRegionRepository {
+	return &taxRegionRepository{
+		db:   db,
+		coll: db.Collection("tax_regions"),
+	}
+}
+
+func (r *taxRegionRepository) FindAll(ctx context.Context) ([]domain.TaxRegion, error) {
+	cursor, err := r.coll.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	regions := []domain.TaxRegion{}
+	err = cursor.All(ctx, &regions)
+	return regions, err
+}
+
+func (r *taxRegionRepository) FindByCode(ctx context.Context, code string) (*domain.TaxRegion, error) {
+	var region domain.TaxRegion
+	err := r.coll.FindOne(ctx, bson.M{"_id": code}).Decode(&region)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrTaxRegionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+func (r *taxRegionRepository) Upsert(ctx context.Context, region *domain.TaxRegion) error {
+	region.UpdatedAt = time.Now()
+	_, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": region.Code},
+		bson.M{"$set": bson.M{
+			"name":       region.Name,
+			"inclusive":  region.Inclusive,
+			"rates":      region.Rates,
+			"updated_by": region.UpdatedBy,
+			"updated_at": region.UpdatedAt,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *taxRegionRepository) Delete(ctx context.Context, code string) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": code})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrTaxRegionNotFound
+	}
+	return nil
+}
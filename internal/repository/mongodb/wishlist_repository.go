@@ -0,0 +1,144 @@
+package mongodb
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type wishlistRepository struct {
+	db   *mongo.Database
+	coll *mongo.Collection
+}
+
+func NewWishlistRepository(db *mongo.Database) domain.WishlistRepository {
+	return &wishlistRepository{
+		db:   db,
+		coll: db.Collection("wishlist_items"),
+	}
+}
+
+func (r *wishlistRepository) Create(ctx context.Context, item *domain.WishlistItem) error {
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = time.Now()
+	result, err := r.coll.InsertOne(ctx, item)
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrWishlistItemExists
+	}
+	if err != nil {
+		return err
+	}
+	item.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *wishlistRepository) FindByID(ctx context.Context, userID, id primitive.ObjectID) (*domain.WishlistItem, error) {
+	pipeline := append([]bson.M{
+		{"$match": bson.M{"_id": id, "user_id": userID}},
+	}, wishlistLookupStages()...)
+
+	cursor, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []domain.WishlistItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, domain.ErrWishlistItemNotFound
+	}
+	return &items[0], nil
+}
+
+var wishlistListSpec = listSpec{
+	sortable: map[string]string{
+		"created_at": "created_at",
+	},
+	defaultSort: domain.SortField{Field: "created_at", Desc: true},
+}
+
+func (r *wishlistRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID, q domain.ListQuery) ([]domain.WishlistItem, *domain.Pagination, error) {
+	filter := bson.M{"user_id": userID}
+	applyCreatedRange(filter, q.Filter)
+
+	return listPage[domain.WishlistItem](ctx, r.coll, filter, q, wishlistListSpec, wishlistLookupStages()...)
+}
+
+func (r *wishlistRepository) FindPriceWatchers(ctx context.Context, productID primitive.ObjectID) ([]domain.WishlistItem, error) {
+	return r.find(ctx, bson.M{"product_id": productID, "notify_price_drop": true})
+}
+
+func (r *wishlistRepository) FindStockWatchers(ctx context.Context, productID primitive.ObjectID, variantID *primitive.ObjectID) ([]domain.WishlistItem, error) {
+	filter := bson.M{"product_id": productID, "notify_back_in_stock": true, "variant_id": nil}
+	if variantID != nil {
+		filter["variant_id"] = *variantID
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *wishlistRepository) find(ctx context.Context, filter bson.M) ([]domain.WishlistItem, error) {
+	cursor, err := r.coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var items []domain.WishlistItem
+	err = cursor.All(ctx, &items)
+	return items, err
+}
+
+func (r *wishlistRepository) UpdatePreferences(ctx context.Context, userID, id primitive.ObjectID, prefs domain.WishlistPreferences) error {
+	result, err := r.coll.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "user_id": userID},
+		bson.M{"$set": bson.M{
+			"notify_price_drop":    prefs.NotifyPriceDrop,
+			"notify_back_in_stock": prefs.NotifyBackInStock,
+			"updated_at":           time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrWishlistItemNotFound
+	}
+	return nil
+}
+
+func (r *wishlistRepository) SetLastNotifiedPrice(ctx context.Context, id primitive.ObjectID, price domain.Money) error {
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_notified_price": price}})
+	return err
+}
+
+func (r *wishlistRepository) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrWishlistItemNotFound
+	}
+	return nil
+}
+
+// wishlistLookupStages reuses the cart item product join. Archived or sold
+// out products stay on the list but are reported as unavailable.
+func wishlistLookupStages() []bson.M {
+	return lookupProduct(bson.M{
+		"product_name":  "$product.name",
+		"product_slug":  "$product.slug",
+		"product_image": bson.M{"$ifNull": bson.A{"$variant.image", "$product.image"}},
+		"product_price": bson.M{"$ifNull": bson.A{"$variant.price", "$product.price"}},
+		"available": bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$product.deleted_at", nil}}, nil}},
+			bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$variant.stock", "$product.stock"}}, 0}},
+		}},
+	})
+}
@@ -0,0 +1,48 @@
+// Package searchtest holds the behaviour every domain.SearchIndex backend
+// must share, so a query returns the same products whichever one is wired.
+package searchtest
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NewIndex returns a backend holding exactly products.
+type NewIndex func(t *testing.T, products []domain.Product) domain.SearchIndex
+
+func Products() []domain.Product {
+	shirts, shoes := primitive.NewObjectID(), primitive.NewObjectID()
+	return []domain.Product{
+		{ID: primitive.NewObjectID(), Name: "Linen Shirt", Description: "Breathable summer shirt", Content: "linen", Price: 890_00, CategoryID: shirts},
+		{ID: primitive.NewObjectID(), Name: "Oxford Shirt", Description: "Classic cotton", Content: "cotton", Price: 1290_00, CategoryID: shirts},
+		{ID: primitive.NewObjectID(), Name: "Running Shoes", Description: "Light shoes with a shirt-friendly colourway", Content: "mesh", Price: 2490_00, CategoryID: shoes},
+	}
+}
+
+// RunMultiTerm checks that a search matches only products with every term.
+func RunMultiTerm(t *testing.T, newIndex NewIndex) {
+	idx := newIndex(t, Products())
+
+	tests := map[string][]string{
+		"linen shirt":  {"Linen Shirt"},
+		"cotton shirt": {"Oxford Shirt"},
+		"shirt":        {"Linen Shirt", "Oxford Shirt", "Running Shoes"},
+	}
+	for query, want := range tests {
+		result, err := idx.Search(context.Background(), domain.ProductSearchQuery{Query: query})
+		require.NoError(t, err, query)
+		assert.False(t, result.Fuzzy, query)
+
+		var names []string
+		for _, hit := range result.Hits {
+			names = append(names, hit.Name)
+		}
+		assert.ElementsMatch(t, want, names, query)
+		assert.EqualValues(t, len(want), result.Total, query)
+	}
+}
@@ -2,15 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
 	"play-to-win-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-type appliedDiscountUseCase struct{}
+type appliedDiscountUseCase struct {
+	categoryRepo domain.CategoryRepository
+	rounding     domain.Rounding
+}
 
-func NewAppliedDiscountUseCase() domain.AppliedDiscountUseCase {
-	return &appliedDiscountUseCase{}
+func NewAppliedDiscountUseCase(cr domain.CategoryRepository, rounding domain.Rounding) domain.AppliedDiscountUseCase {
+	return &appliedDiscountUseCase{
+		categoryRepo: cr,
+		rounding:     rounding,
+	}
 }
 
 func validateCartItems(cartItems []domain.CartItem) error {
@@ -32,102 +40,256 @@ func validateCartItems(cartItems []domain.CartItem) error {
 	return nil
 }
 
-func calculateTotalPrice(cartItems []domain.CartItem) float64 {
-	var totalPrice float64
+func calculateTotalPrice(cartItems []domain.CartItem) domain.Money {
+	var totalPrice domain.Money
 	for _, item := range cartItems {
 		totalPrice += item.TotalPrice
 	}
 	return totalPrice
 }
 
-func (uc *appliedDiscountUseCase) CalculateFixedAmountDiscount(ctx context.Context, cartItems []domain.CartItem, amount float64) (float64, error) {
+// lineTotals returns the total of each line that matches, and zero for the
+// rest.
+func lineTotals(cartItems []domain.CartItem, match func(domain.CartItem) bool) []domain.Money {
+	totals := make([]domain.Money, len(cartItems))
+	for i, item := range cartItems {
+		if match(item) {
+			totals[i] = item.TotalPrice
+		}
+	}
+	return totals
+}
+
+func sumMoney(amounts []domain.Money) domain.Money {
+	var total domain.Money
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+func allItems(domain.CartItem) bool { return true }
+
+// spread allocates an order-level discount over the lines by their totals,
+// never more than they add up to.
+func spread(cartItems []domain.CartItem, discount domain.Money) []domain.DiscountAllocation {
+	totals := lineTotals(cartItems, allItems)
+	return domain.LineAllocations(cartItems, domain.Allocate(domain.MinMoney(discount, calculateTotalPrice(cartItems)), totals))
+}
+
+func (uc *appliedDiscountUseCase) CalculateFixedAmountDiscount(ctx context.Context, cartItems []domain.CartItem, amount domain.Money) ([]domain.DiscountAllocation, error) {
 	if err := validateCartItems(cartItems); err != nil {
-		return 0, err
+		return nil, err
 	}
 	if amount < 0 {
-		return 0, domain.ErrInvalidDiscountAmount
+		return nil, domain.ErrInvalidDiscountAmount
 	}
-
-	totalPrice := calculateTotalPrice(cartItems)
-	return math.Max(0, totalPrice-amount), nil
+	return spread(cartItems, amount), nil
 }
 
-func (uc *appliedDiscountUseCase) CalculatePercentageDiscount(ctx context.Context, cartItems []domain.CartItem, percentage float64) (float64, error) {
+func (uc *appliedDiscountUseCase) CalculatePercentageDiscount(ctx context.Context, cartItems []domain.CartItem, percentage float64) ([]domain.DiscountAllocation, error) {
 	if err := validateCartItems(cartItems); err != nil {
-		return 0, err
+		return nil, err
 	}
 	if percentage < 0 || percentage > 100 {
-		return 0, domain.ErrInvalidDiscountPercentage
+		return nil, domain.ErrInvalidDiscountPercentage
 	}
-
-	totalPrice := calculateTotalPrice(cartItems)
-	discount := totalPrice * (percentage / 100)
-	return math.Max(0, totalPrice-discount), nil
+	return domain.LineAllocations(cartItems, uc.rounding.Percent(lineTotals(cartItems, allItems), percentage)), nil
 }
 
-func (uc *appliedDiscountUseCase) CalculateCategoryDiscount(ctx context.Context, cartItems []domain.CartItem, category string, percentage float64) (float64, error) {
+func (uc *appliedDiscountUseCase) CalculateCategoryDiscount(ctx context.Context, cartItems []domain.CartItem, category string, percentage float64) ([]domain.DiscountAllocation, error) {
 	if err := validateCartItems(cartItems); err != nil {
-		return 0, err
+		return nil, err
 	}
 	if category == "" {
-		return 0, domain.ErrInvalidCategory
+		return nil, domain.ErrInvalidCategory
 	}
 	if percentage < 0 || percentage > 100 {
-		return 0, domain.ErrInvalidDiscountPercentage
+		return nil, domain.ErrInvalidDiscountPercentage
 	}
 
-	var categoryTotal float64
-	var hasCategory bool
+	categories, err := uc.categorySubtree(ctx, category)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, item := range cartItems {
-		if item.Category == category {
-			hasCategory = true
-			discount := item.TotalPrice * (percentage / 100)
-			categoryTotal += item.TotalPrice - discount
-		} else {
-			categoryTotal += item.TotalPrice
-		}
+	totals := lineTotals(cartItems, func(item domain.CartItem) bool { return categories[item.CategoryID] })
+	if sumMoney(totals) == 0 {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	return domain.LineAllocations(cartItems, uc.rounding.Percent(totals, percentage)), nil
+}
+
+func (uc *appliedDiscountUseCase) CalculateVariantDiscount(ctx context.Context, cartItems []domain.CartItem, sku string, percentage float64) ([]domain.DiscountAllocation, error) {
+	if err := validateCartItems(cartItems); err != nil {
+		return nil, err
+	}
+	if sku == "" {
+		return nil, domain.ErrInvalidSKU
+	}
+	if percentage < 0 || percentage > 100 {
+		return nil, domain.ErrInvalidDiscountPercentage
 	}
 
-	if !hasCategory {
-		return 0, domain.ErrCategoryNotFound
+	totals := lineTotals(cartItems, func(item domain.CartItem) bool { return item.VariantSKU == sku })
+	if sumMoney(totals) == 0 {
+		return nil, domain.ErrVariantNotFound
 	}
 
-	return math.Max(0, categoryTotal), nil
+	return domain.LineAllocations(cartItems, uc.rounding.Percent(totals, percentage)), nil
+}
+
+// categorySubtree resolves a rule's category to the IDs of it and every
+// category under it. Rules may name the category by ID, or by name for rules
+// written before IDs were accepted; names aren't unique, so an ID is the only
+// way to be sure which subtree is meant.
+func (uc *appliedDiscountUseCase) categorySubtree(ctx context.Context, ref string) (map[primitive.ObjectID]bool, error) {
+	ids := map[primitive.ObjectID]bool{}
+
+	var (
+		category *domain.Category
+		err      error
+	)
+	if primitive.IsValidObjectID(ref) {
+		category, err = uc.categoryRepo.FindByID(ctx, ref)
+	} else {
+		category, err = uc.categoryRepo.FindByName(ctx, ref)
+	}
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ids[category.ID] = true
+
+	descendants, err := uc.categoryRepo.FindByPathPrefix(ctx, category.SubtreePath())
+	if err != nil {
+		return nil, err
+	}
+	for _, descendant := range descendants {
+		ids[descendant.ID] = true
+	}
+	return ids, nil
 }
 
-func (uc *appliedDiscountUseCase) CalculatePointsDiscount(ctx context.Context, cartItems []domain.CartItem, points int) (float64, error) {
+// CalculatePointsDiscount takes a baht off per point, up to 20% of the cart.
+func (uc *appliedDiscountUseCase) CalculatePointsDiscount(ctx context.Context, cartItems []domain.CartItem, points int) ([]domain.DiscountAllocation, error) {
 	if err := validateCartItems(cartItems); err != nil {
-		return 0, err
+		return nil, err
 	}
 	if points < 0 {
-		return 0, domain.ErrInvalidPoints
+		return nil, domain.ErrInvalidPoints
 	}
 
-	totalPrice := calculateTotalPrice(cartItems)
-	maxDiscount := totalPrice * 0.20
-	pointValue := float64(points) 
-	discount := math.Min(pointValue, maxDiscount)
-	return math.Max(0, totalPrice-discount), nil
+	maxDiscount := calculateTotalPrice(cartItems).Percent(20, uc.rounding.Mode)
+	pointValue := domain.MoneyFromFloat(float64(points))
+	return spread(cartItems, domain.MinMoney(pointValue, maxDiscount)), nil
 }
 
-func (uc *appliedDiscountUseCase) CalculateSpecialDiscount(ctx context.Context, cartItems []domain.CartItem, threshold, discountAmount float64) (float64, error) {
+func (uc *appliedDiscountUseCase) CalculateSpecialDiscount(ctx context.Context, cartItems []domain.CartItem, threshold, discountAmount domain.Money) ([]domain.DiscountAllocation, error) {
 	if err := validateCartItems(cartItems); err != nil {
-		return 0, err
+		return nil, err
 	}
 	if threshold <= 0 {
-		return 0, domain.ErrInvalidThreshold
+		return nil, domain.ErrInvalidThreshold
 	}
 	if discountAmount <= 0 {
-		return 0, domain.ErrInvalidDiscountAmount
+		return nil, domain.ErrInvalidDiscountAmount
+	}
+
+	discountTimes := int(calculateTotalPrice(cartItems) / threshold)
+	return spread(cartItems, discountAmount.Times(discountTimes)), nil
+}
+
+func (uc *appliedDiscountUseCase) CalculateBuyXGetYDiscount(ctx context.Context, cartItems []domain.CartItem, offer domain.BuyXGetY) ([]domain.DiscountAllocation, error) {
+	if err := validateCartItems(cartItems); err != nil {
+		return nil, err
+	}
+	if !offer.Valid() {
+		return nil, domain.ErrInvalidOffer
+	}
+
+	isBuy, err := uc.itemMatcher(ctx, offer.Buy)
+	if err != nil {
+		return nil, err
+	}
+	isGet, err := uc.itemMatcher(ctx, offer.Get)
+	if err != nil {
+		return nil, err
+	}
+	return domain.AllocateBuyXGetY(cartItems, offer, uc.rounding, isBuy, isGet), nil
+}
+
+func (uc *appliedDiscountUseCase) CalculateBundleDiscount(ctx context.Context, cartItems []domain.CartItem, bundle domain.Bundle) ([]domain.DiscountAllocation, error) {
+	if err := validateCartItems(cartItems); err != nil {
+		return nil, err
+	}
+	if !bundle.Valid() {
+		return nil, domain.ErrInvalidBundle
+	}
+	return domain.AllocateBundle(cartItems, bundle), nil
+}
+
+// CalculateTieredDiscount measures the qualifying items against the tiers.
+// A percentage tier discounts the qualifying items; a fixed tier comes off
+// them but never below zero.
+func (uc *appliedDiscountUseCase) CalculateTieredDiscount(ctx context.Context, cartItems []domain.CartItem, pricing domain.TieredPricing) ([]domain.DiscountAllocation, error) {
+	if err := validateCartItems(cartItems); err != nil {
+		return nil, err
+	}
+	if err := pricing.Validate(); err != nil {
+		return nil, err
 	}
 
-	totalPrice := calculateTotalPrice(cartItems)
-	if totalPrice < threshold {
-		return totalPrice, nil
+	qualifies := allItems
+	if pricing.Category != "" {
+		var err error
+		if qualifies, err = uc.itemMatcher(ctx, domain.ItemTarget{Category: pricing.Category}); err != nil {
+			return nil, err
+		}
 	}
 
-	discountTimes := math.Floor(totalPrice / threshold)
-	totalDiscount := discountTimes * discountAmount
-	return math.Max(0, totalPrice-totalDiscount), nil
+	var quantity int
+	for _, item := range cartItems {
+		if qualifies(item) {
+			quantity += item.Quantity
+		}
+	}
+	totals := lineTotals(cartItems, qualifies)
+	spend := sumMoney(totals)
+
+	measure := spend.Float64()
+	if pricing.Basis == domain.TierBasisQuantity {
+		measure = float64(quantity)
+	}
+
+	tier := pricing.Tier(measure)
+	if tier == nil {
+		return nil, nil
+	}
+	if tier.Percentage > 0 {
+		return domain.LineAllocations(cartItems, uc.rounding.Percent(totals, tier.Percentage)), nil
+	}
+	return domain.LineAllocations(cartItems, domain.Allocate(domain.MinMoney(tier.Amount, spend), totals)), nil
+}
+
+// itemMatcher matches the target's products, and items anywhere under its
+// category like the category discount does.
+func (uc *appliedDiscountUseCase) itemMatcher(ctx context.Context, target domain.ItemTarget) (func(domain.CartItem) bool, error) {
+	products := map[primitive.ObjectID]bool{}
+	for _, id := range target.ProductIDs {
+		products[id] = true
+	}
+	categories := map[primitive.ObjectID]bool{}
+	if target.Category != "" {
+		var err error
+		if categories, err = uc.categorySubtree(ctx, target.Category); err != nil {
+			return nil, err
+		}
+	}
+	return func(item domain.CartItem) bool {
+		return products[item.ProductId] || categories[item.CategoryID]
+	}, nil
 }
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAppliedDiscountUseCase_CalculateCategoryDiscount_MatchesCategoryIDs(t *testing.T) {
+	clothing := domain.Category{ID: primitive.NewObjectID(), Name: "Clothing", Path: domain.CategoryRootPath}
+	shirts := domain.Category{ID: primitive.NewObjectID(), Name: "Shirts", Path: clothing.SubtreePath()}
+	// Another category with the same name, elsewhere in the tree.
+	saleShirts := domain.Category{ID: primitive.NewObjectID(), Name: "Shirts", Path: domain.CategoryRootPath}
+
+	repo := new(MockCategoryRepository)
+	repo.On("FindByID", mock.Anything, clothing.ID.Hex()).Return(&clothing, nil)
+	repo.On("FindByID", mock.Anything, saleShirts.ID.Hex()).Return(&saleShirts, nil)
+	repo.On("FindByPathPrefix", mock.Anything, clothing.SubtreePath()).Return([]domain.Category{shirts}, nil)
+	repo.On("FindByPathPrefix", mock.Anything, saleShirts.SubtreePath()).Return([]domain.Category{}, nil)
+	uc := NewAppliedDiscountUseCase(repo, domain.Rounding{Mode: domain.RoundHalfEven, Scope: domain.RoundPerOrder})
+
+	shirt := domain.CartItem{ProductId: primitive.NewObjectID(), CategoryID: shirts.ID, Category: "Shirts", Quantity: 1, UnitPrice: 100_00, TotalPrice: 100_00}
+	saleShirt := domain.CartItem{ProductId: primitive.NewObjectID(), CategoryID: saleShirts.ID, Category: "Shirts", Quantity: 1, UnitPrice: 100_00, TotalPrice: 100_00}
+	// The name says Clothing but the product isn't in that subtree.
+	misnamed := domain.CartItem{ProductId: primitive.NewObjectID(), Category: "Clothing", Quantity: 1, UnitPrice: 100_00, TotalPrice: 100_00}
+	items := []domain.CartItem{shirt, saleShirt, misnamed}
+
+	allocations, err := uc.CalculateCategoryDiscount(context.Background(), items, clothing.ID.Hex(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DiscountAllocation{{ProductID: shirt.ProductId, Quantity: 1, Amount: 10_00}}, allocations)
+
+	allocations, err = uc.CalculateCategoryDiscount(context.Background(), items, saleShirts.ID.Hex(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DiscountAllocation{{ProductID: saleShirt.ProductId, Quantity: 1, Amount: 10_00}}, allocations)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// endOfTime bounds open-ended conflict scans.
+var endOfTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// conflictChecker finds campaigns whose discounts would stack with each
+// other. Campaign and discount rule changes both go through it, since either
+// can introduce an overlap.
+type conflictChecker struct {
+	campaignRepo     domain.CampaignRepository
+	discountRuleRepo domain.DiscountRuleRepository
+}
+
+// check compares campaign, as it would be saved with rules, against every
+// other campaign overlapping its window.
+func (cc conflictChecker) check(ctx context.Context, campaign *domain.Campaign, rules []domain.DiscountRule) ([]domain.CampaignConflict, error) {
+	others, err := cc.campaignRepo.FindOverlapping(ctx, campaign.StartDate, campaign.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	rulesByCampaign, err := cc.rulesByCampaign(ctx, others)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []domain.CampaignConflict{}
+	for _, other := range others {
+		if conflict := domain.DetectCampaignConflict(*campaign, rules, other, rulesByCampaign[other.ID]); conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// all reports every conflict between campaigns that have not ended by now.
+func (cc conflictChecker) all(ctx context.Context, now time.Time) ([]domain.CampaignConflict, error) {
+	campaigns, err := cc.campaignRepo.FindOverlapping(ctx, now, endOfTime)
+	if err != nil {
+		return nil, err
+	}
+	rulesByCampaign, err := cc.rulesByCampaign(ctx, campaigns)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []domain.CampaignConflict{}
+	for i := range campaigns {
+		for j := i + 1; j < len(campaigns); j++ {
+			a, b := campaigns[i], campaigns[j]
+			if conflict := domain.DetectCampaignConflict(a, rulesByCampaign[a.ID], b, rulesByCampaign[b.ID]); conflict != nil {
+				conflicts = append(conflicts, *conflict)
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+func (cc conflictChecker) rulesByCampaign(ctx context.Context, campaigns []domain.Campaign) (map[primitive.ObjectID][]domain.DiscountRule, error) {
+	byCampaign := make(map[primitive.ObjectID][]domain.DiscountRule, len(campaigns))
+	if len(campaigns) == 0 {
+		return byCampaign, nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		ids = append(ids, campaign.ID)
+	}
+	rules, err := cc.discountRuleRepo.FindByCampaignIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		byCampaign[rule.CampaignID] = append(byCampaign[rule.CampaignID], rule)
+	}
+	return byCampaign, nil
+}
+
+// resolveConflicts splits conflicts into the ones that reject the change and
+// the warnings that are merely reported back.
+func resolveConflicts(conflicts []domain.CampaignConflict) ([]domain.CampaignConflict, error) {
+	if blocking := domain.BlockingConflicts(conflicts); len(blocking) > 0 {
+		return blocking, domain.ErrCampaignConflict
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type campaignSimulationUseCase struct {
+	orderRepo              domain.OrderRepository
+	segmentUseCase         domain.SegmentUseCase
+	appliedDiscountUseCase domain.AppliedDiscountUseCase
+	exchangeRateUseCase    domain.ExchangeRateUseCase
+	rounding               domain.Rounding
+}
+
+func NewCampaignSimulationUseCase(or domain.OrderRepository, su domain.SegmentUseCase, adu domain.AppliedDiscountUseCase, eru domain.ExchangeRateUseCase, rounding domain.Rounding) domain.CampaignSimulationUseCase {
+	return &campaignSimulationUseCase{
+		orderRepo:              or,
+		segmentUseCase:         su,
+		appliedDiscountUseCase: adu,
+		exchangeRateUseCase:    eru,
+		rounding:               rounding,
+	}
+}
+
+// Simulate replays the draft campaign on its own, ignoring its window and any
+// other campaign. Segment targeting is checked against customers as they are
+// today, and orders in other currencies are converted at today's rates.
+func (uc *campaignSimulationUseCase) Simulate(ctx context.Context, request domain.CampaignSimulationRequest) (*domain.CampaignSimulation, error) {
+	if len(request.Rules) == 0 {
+		return nil, domain.ErrInvalidSimulation
+	}
+	for i := range request.Rules {
+		rule := &request.Rules[i]
+		if !domain.ValidDiscountType(rule.DiscuntType) {
+			return nil, domain.ErrInvalidSimulation
+		}
+		if err := validateDiscountRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	campaign := request.Campaign
+	if !campaign.ValidLimits() {
+		return nil, domain.ErrInvalidCampaignLimits
+	}
+
+	to := time.Now()
+	if request.To != nil {
+		to = *request.To
+	}
+	from := to.Add(-domain.DefaultSimulationWindow)
+	if request.From != nil {
+		from = *request.From
+	}
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidSimulationRange
+	}
+
+	result := &domain.CampaignSimulation{From: from, To: to}
+	customers := map[primitive.ObjectID]*customerEligibility{}
+	redeemed := map[primitive.ObjectID]int{}
+	var spent domain.Money
+	var redemptions int
+
+	err := uc.orderRepo.EachBetween(ctx, from, to, func(order *domain.Order) error {
+		items := orderCartItems(order.Items)
+		subtotal, err := uc.exchangeRateUseCase.Convert(ctx, calculateTotalPrice(items), order.Currency, domain.BaseCurrency)
+		if err != nil {
+			return err
+		}
+		result.Orders++
+		result.GrossRevenue += subtotal
+		if result.ExhaustedAt != nil {
+			return nil
+		}
+
+		customer, ok := customers[order.UserID]
+		if !ok {
+			customer = &customerEligibility{
+				segmentUseCase: uc.segmentUseCase,
+				customerID:     order.UserID,
+				campaigns:      map[primitive.ObjectID]bool{},
+			}
+			customers[order.UserID] = customer
+		}
+		eligible, err := customer.eligible(ctx, campaign.ID, campaign.SegmentIDs)
+		if err != nil || !eligible {
+			return err
+		}
+		if campaign.MaxRedemptionsPerCustomer > 0 && redeemed[order.UserID] >= campaign.MaxRedemptionsPerCustomer {
+			return nil
+		}
+
+		var discount domain.Money
+		for _, rule := range request.Rules {
+			if !rule.AppliesTo(order.Currency) {
+				continue
+			}
+			ruleItems, err := conditionItems(ctx, rule, items, customer)
+			if err != nil {
+				return err
+			}
+			amount, _ := ruleDiscount(ctx, uc.appliedDiscountUseCase, uc.rounding.Mode, rule, ruleItems)
+			discount += amount
+		}
+		if discount, err = uc.exchangeRateUseCase.Convert(ctx, discount, order.Currency, domain.BaseCurrency); err != nil {
+			return err
+		}
+		discount = domain.MinMoney(discount, subtotal)
+		if campaign.Budget > 0 {
+			discount = domain.MinMoney(discount, campaign.Budget-spent)
+		}
+		if discount <= 0 {
+			return nil
+		}
+
+		if redeemed[order.UserID] == 0 {
+			result.Customers++
+		}
+		redeemed[order.UserID]++
+		redemptions++
+		spent += discount
+		result.AffectedOrders++
+		result.ProjectedDiscount += discount
+
+		if (campaign.Budget > 0 && spent >= campaign.Budget) || (campaign.MaxRedemptions > 0 && redemptions >= campaign.MaxRedemptions) {
+			exhaustedAt := order.CreatedAt
+			result.ExhaustedAt = &exhaustedAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.ProjectedRevenue = result.GrossRevenue - result.ProjectedDiscount
+	if result.AffectedOrders > 0 {
+		result.AverageDiscount = result.ProjectedDiscount.Divide(result.AffectedOrders, domain.RoundHalfEven)
+	}
+	if result.GrossRevenue > 0 {
+		result.MarginImpact = result.ProjectedDiscount.Float64() / result.GrossRevenue.Float64() * 100
+	}
+	return result, nil
+}
+
+// orderCartItems turns a placed order back into the cart the discount
+// calculators work on.
+func orderCartItems(items []domain.OrderItem) []domain.CartItem {
+	cartItems := make([]domain.CartItem, 0, len(items))
+	for _, item := range items {
+		cartItems = append(cartItems, domain.CartItem{
+			ProductId:   item.ProductID,
+			VariantId:   item.VariantID,
+			ProductName: item.ProductName,
+			VariantSKU:  item.VariantSKU,
+			CategoryID:  item.CategoryID,
+			Category:    item.Category,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+			OnSale:      item.OnSale,
+			TaxClass:    item.TaxClass,
+		})
+	}
+	return cartItems
+}
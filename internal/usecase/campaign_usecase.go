@@ -7,24 +7,81 @@ import (
 	"play-to-win-api/internal/constants"
 	"play-to-win-api/internal/domain"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type compaginUseCase struct {
 	campaignRepo domain.CampaignRepository
+	orderRepo    domain.OrderRepository
+	segmentRepo  domain.SegmentRepository
+	publisher    domain.EventPublisher
+	conflicts    conflictChecker
 }
 
-func NewCampaignUseCase(cr domain.CampaignRepository) domain.CampaignUseCase {
+func NewCampaignUseCase(cr domain.CampaignRepository, dr domain.DiscountRuleRepository, or domain.OrderRepository, sr domain.SegmentRepository, ep domain.EventPublisher) domain.CampaignUseCase {
 	return &compaginUseCase{
 		campaignRepo: cr,
+		orderRepo:    or,
+		segmentRepo:  sr,
+		publisher:    ep,
+		conflicts:    conflictChecker{campaignRepo: cr, discountRuleRepo: dr},
 	}
 }
 
+// Create stores the campaign inactive; the scheduler switches it on once its
+// window opens.
 func (uc *compaginUseCase) Create(ctx context.Context, campaign *domain.Campaign) error {
+	if campaign.StartDate.IsZero() {
+		campaign.StartDate = time.Now()
+	}
+	if campaign.EndDate.IsZero() {
+		campaign.EndDate = campaign.StartDate.Add(domain.DefaultCampaignLength)
+	}
+	if !campaign.EndDate.After(campaign.StartDate) {
+		return domain.ErrInvalidCampaignWindow
+	}
+	if !campaign.ValidLimits() {
+		return domain.ErrInvalidCampaignLimits
+	}
+	if err := uc.checkSegments(ctx, campaign.SegmentIDs); err != nil {
+		return err
+	}
+
+	conflicts, err := uc.conflicts.check(ctx, campaign, nil)
+	if err != nil {
+		return err
+	}
+	if campaign.Conflicts, err = resolveConflicts(conflicts); err != nil {
+		return err
+	}
+
+	campaign.IsActive = false
+	campaign.StartedAt, campaign.EndedAt, campaign.DeletedAt = nil, nil, nil
+	campaign.Spent, campaign.Redemptions, campaign.ExhaustedAt = 0, 0, nil
 	return uc.campaignRepo.Create(ctx, campaign)
 }
 
+func (uc *compaginUseCase) checkSegments(ctx context.Context, ids []primitive.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	unique := map[primitive.ObjectID]bool{}
+	for _, id := range ids {
+		unique[id] = true
+	}
+
+	segments, err := uc.segmentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(segments) != len(unique) {
+		return domain.ErrSegmentNotFound
+	}
+	return nil
+}
+
 func (uc *compaginUseCase) GetByID(ctx context.Context, id string) (*domain.Campaign, error) {
 	if !primitive.IsValidObjectID(id) {
 		return nil, domain.ErrInvalidCampaignID
@@ -40,12 +97,93 @@ func (uc *compaginUseCase) GetByID(ctx context.Context, id string) (*domain.Camp
 	return campaign, nil
 }
 
-func (uc *compaginUseCase) GetAll(ctx context.Context) ([]domain.Campaign, error) {
-	return uc.campaignRepo.FindAll(ctx)
+func (uc *compaginUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Campaign, *domain.Pagination, error) {
+	return uc.campaignRepo.FindAll(ctx, query)
 }
 
 func (uc *compaginUseCase) Update(ctx context.Context, campaign *domain.Campaign) error {
-	return uc.campaignRepo.Update(ctx, campaign)
+	existing, err := uc.campaignRepo.FindByID(ctx, campaign.ID.Hex())
+	if err != nil {
+		return domain.ErrCampaignNotFound
+	}
+
+	if campaign.StartDate.IsZero() {
+		campaign.StartDate = existing.StartDate
+	}
+	if campaign.EndDate.IsZero() {
+		campaign.EndDate = existing.EndDate
+	}
+	if !campaign.EndDate.After(campaign.StartDate) {
+		return domain.ErrInvalidCampaignWindow
+	}
+	if !campaign.ValidLimits() {
+		return domain.ErrInvalidCampaignLimits
+	}
+	if err := uc.checkSegments(ctx, campaign.SegmentIDs); err != nil {
+		return err
+	}
+
+	campaign.StartedAt, campaign.EndedAt = existing.StartedAt, existing.EndedAt
+	if !campaign.StartDate.Equal(existing.StartDate) || !campaign.EndDate.Equal(existing.EndDate) {
+		// A rescheduled campaign goes back to the scheduler unless it is
+		// already running and its new window still covers now.
+		if !existing.IsActive || !campaign.InWindow(time.Now()) {
+			campaign.IsActive = false
+			campaign.StartedAt = nil
+		}
+		campaign.EndedAt = nil
+	}
+
+	rules, err := uc.conflicts.discountRuleRepo.FindByCampaignIDs(ctx, []primitive.ObjectID{campaign.ID})
+	if err != nil {
+		return err
+	}
+	conflicts, err := uc.conflicts.check(ctx, campaign, rules)
+	if err != nil {
+		return err
+	}
+	if campaign.Conflicts, err = resolveConflicts(conflicts); err != nil {
+		return err
+	}
+
+	// The counters are left out of the update so concurrent checkouts are
+	// never overwritten, then echoed back for the response.
+	campaign.Spent, campaign.Redemptions, campaign.ExhaustedAt = 0, 0, nil
+	campaign.DeletedAt = nil
+	if err := uc.campaignRepo.Update(ctx, campaign); err != nil {
+		return err
+	}
+	campaign.Spent, campaign.Redemptions, campaign.ExhaustedAt = existing.Spent, existing.Redemptions, existing.ExhaustedAt
+	return nil
+}
+
+func (uc *compaginUseCase) GetStats(ctx context.Context, id string, query domain.CampaignStatsQuery) (*domain.CampaignStats, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, domain.ErrInvalidCampaignID
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, domain.ErrInvalidStatsRange
+	}
+	if query.TopProducts <= 0 || query.TopProducts > domain.MaxListLimit {
+		query.TopProducts = domain.DefaultCampaignTopProducts
+	}
+
+	campaign, err := uc.campaignRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrCampaignNotFound
+	}
+
+	stats, err := uc.orderRepo.CampaignStats(ctx, campaign.ID, query)
+	if err != nil {
+		return nil, err
+	}
+	stats.CampaignName = campaign.Name
+	stats.From, stats.To = query.From, query.To
+	return stats, nil
+}
+
+func (uc *compaginUseCase) GetConflicts(ctx context.Context) ([]domain.CampaignConflict, error) {
+	return uc.conflicts.all(ctx, time.Now())
 }
 
 func (uc *compaginUseCase) Delete(ctx context.Context, id string) error {
@@ -54,4 +192,62 @@ func (uc *compaginUseCase) Delete(ctx context.Context, id string) error {
 	}
 
 	return uc.campaignRepo.Delete(ctx, id)
-}
\ No newline at end of file
+}
+
+func (uc *compaginUseCase) Restore(ctx context.Context, id string) (*domain.Campaign, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, domain.ErrInvalidCampaignID
+	}
+
+	if err := uc.campaignRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.campaignRepo.FindByID(ctx, id)
+}
+
+func (uc *compaginUseCase) GetArchived(ctx context.Context, query domain.ListQuery) ([]domain.Campaign, *domain.Pagination, error) {
+	return uc.campaignRepo.FindArchived(ctx, query)
+}
+
+// ApplySchedule ends campaigns whose window has closed and starts those whose
+// window has opened, publishing an event for each transition.
+func (uc *compaginUseCase) ApplySchedule(ctx context.Context, at time.Time) (int, int, error) {
+	ending, err := uc.campaignRepo.FindDueToEnd(ctx, at)
+	if err != nil {
+		return 0, 0, err
+	}
+	ended, err := uc.transition(ctx, ending, at, uc.campaignRepo.MarkEnded, domain.EventCampaignEnded)
+	if err != nil {
+		return 0, ended, err
+	}
+
+	starting, err := uc.campaignRepo.FindDueToStart(ctx, at)
+	if err != nil {
+		return 0, ended, err
+	}
+	started, err := uc.transition(ctx, starting, at, uc.campaignRepo.MarkStarted, domain.EventCampaignStarted)
+	return started, ended, err
+}
+
+func (uc *compaginUseCase) transition(ctx context.Context, campaigns []domain.Campaign, at time.Time, mark func(context.Context, primitive.ObjectID, time.Time) (bool, error), eventType string) (int, error) {
+	count := 0
+	for _, campaign := range campaigns {
+		changed, err := mark(ctx, campaign.ID, at)
+		if err != nil {
+			return count, err
+		}
+		if !changed {
+			continue
+		}
+		count++
+		if err := uc.publisher.Publish(ctx, domain.NewEvent(eventType, domain.CampaignTransition{
+			CampaignID: campaign.ID,
+			Name:       campaign.Name,
+			StartDate:  campaign.StartDate,
+			EndDate:    campaign.EndDate,
+		})); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
@@ -12,19 +12,101 @@ import (
 )
 
 type cartItemUseCase struct {
-	cartItemRepo domain.CartItemRepository
+	cartItemRepo        domain.CartItemRepository
+	productRepo         domain.ProductRepository
+	categoryRepo        domain.CategoryRepository
+	cartRepo            domain.CartRepository
+	exchangeRateUseCase domain.ExchangeRateUseCase
 }
 
-func NewCartItemUseCase(cr domain.CartItemRepository) domain.CartItemUseCase {
+func NewCartItemUseCase(cr domain.CartItemRepository, pr domain.ProductRepository, catr domain.CategoryRepository, car domain.CartRepository, eru domain.ExchangeRateUseCase) domain.CartItemUseCase {
 	return &cartItemUseCase{
-		cartItemRepo: cr,
+		cartItemRepo:        cr,
+		productRepo:         pr,
+		categoryRepo:        catr,
+		cartRepo:            car,
+		exchangeRateUseCase: eru,
 	}
 }
 
 func (uc *cartItemUseCase) Create(ctx context.Context, cartItem *domain.CartItem) error {
+	if err := uc.priceFromProduct(ctx, cartItem); err != nil {
+		return err
+	}
 	return uc.cartItemRepo.Create(ctx, cartItem)
 }
 
+// priceFromProduct checks stock for the selected product or variant and
+// prices the line from the catalogue rather than the request body, in the
+// cart's currency: from the product's price list when it has an entry,
+// otherwise converted at the current exchange rate. The category comes from
+// the product too, since category discounts key off it.
+func (uc *cartItemUseCase) priceFromProduct(ctx context.Context, cartItem *domain.CartItem) error {
+	product, err := uc.productRepo.FindByID(ctx, cartItem.ProductId.Hex())
+	if err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	var variant *domain.ProductVariant
+	if cartItem.VariantId != nil {
+		if variant = product.Variant(*cartItem.VariantId); variant == nil {
+			return domain.ErrVariantNotFound
+		}
+	} else if len(product.Variants) > 0 {
+		return domain.ErrVariantRequired
+	}
+
+	if product.StockFor(variant) < cartItem.Quantity {
+		return domain.ErrInsufficientStock
+	}
+
+	currency, err := uc.cartCurrency(ctx, cartItem)
+	if err != nil {
+		return err
+	}
+	price, ok := product.ListPrice(currency, variant)
+	if !ok {
+		if price, err = uc.exchangeRateUseCase.Convert(ctx, product.PriceFor(variant), domain.BaseCurrency, currency); err != nil {
+			return err
+		}
+	}
+
+	cartItem.CategoryID = product.CategoryID
+	cartItem.Category = ""
+	if !product.CategoryID.IsZero() {
+		category, err := uc.categoryRepo.FindByID(ctx, product.CategoryID.Hex())
+		if err != nil && !errors.Is(err, domain.ErrCategoryNotFound) {
+			return err
+		}
+		if category != nil {
+			cartItem.Category = category.Name
+		}
+	}
+
+	cartItem.Currency = currency
+	cartItem.TaxClass = product.TaxClass
+	cartItem.UnitPrice = price
+	cartItem.TotalPrice = cartItem.UnitPrice.Times(cartItem.Quantity)
+	return nil
+}
+
+func (uc *cartItemUseCase) cartCurrency(ctx context.Context, cartItem *domain.CartItem) (domain.Currency, error) {
+	cartID := cartItem.CartId
+	if cartID.IsZero() {
+		existing, err := uc.cartItemRepo.FindByID(ctx, cartItem.ID.Hex())
+		if err != nil {
+			return "", err
+		}
+		cartID = existing.CartId
+	}
+
+	cart, err := uc.cartRepo.FindByID(ctx, cartID.Hex())
+	if err != nil {
+		return "", domain.ErrCartNotFound
+	}
+	return cart.Currency.OrBase(), nil
+}
+
 func (uc *cartItemUseCase) GetByCartID(ctx context.Context, cartID string) ([]domain.CartItem, error) {
 	if !primitive.IsValidObjectID(cartID) {
 		return nil, domain.ErrInvalidCartID
@@ -55,11 +137,33 @@ func (uc *cartItemUseCase) GetByID(ctx context.Context, id string) (*domain.Cart
 	return cartItem, nil
 }
 
-func (uc *cartItemUseCase) GetAll(ctx context.Context) ([]domain.CartItem, error) {
-	return uc.cartItemRepo.FindAll(ctx)
+func (uc *cartItemUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.CartItem, *domain.Pagination, error) {
+	return uc.cartItemRepo.FindAll(ctx, query)
 }
 
+// Update changes the quantity of a stored line and reprices it. The line
+// keeps its cart, product and variant; the price is never taken from the body.
 func (uc *cartItemUseCase) Update(ctx context.Context, cartItem *domain.CartItem) error {
+	existing, err := uc.cartItemRepo.FindByID(ctx, cartItem.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if !cartItem.ProductId.IsZero() && cartItem.ProductId != existing.ProductId {
+		return domain.ErrCartItemProductChanged
+	}
+	if cartItem.VariantId != nil && (existing.VariantId == nil || *cartItem.VariantId != *existing.VariantId) {
+		return domain.ErrCartItemProductChanged
+	}
+
+	cartItem.CartId = existing.CartId
+	cartItem.ProductId = existing.ProductId
+	cartItem.VariantId = existing.VariantId
+	if cartItem.Quantity <= 0 {
+		cartItem.Quantity = existing.Quantity
+	}
+	if err := uc.priceFromProduct(ctx, cartItem); err != nil {
+		return err
+	}
 	return uc.cartItemRepo.Update(ctx, cartItem)
 }
 
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// storedCartItems holds one stored line and records what Update saw.
+type storedCartItems struct {
+	domain.CartItemRepository
+	item    domain.CartItem
+	created []domain.CartItem
+	updated []domain.CartItem
+}
+
+func (r *storedCartItems) FindByID(ctx context.Context, id string) (*domain.CartItem, error) {
+	if r.item.ID.Hex() != id {
+		return nil, domain.ErrCartItemNotFound
+	}
+	item := r.item
+	return &item, nil
+}
+
+func (r *storedCartItems) Create(ctx context.Context, cartItem *domain.CartItem) error {
+	r.created = append(r.created, *cartItem)
+	return nil
+}
+
+func (r *storedCartItems) Update(ctx context.Context, cartItem *domain.CartItem) error {
+	r.updated = append(r.updated, *cartItem)
+	return nil
+}
+
+type pricedProduct struct {
+	domain.ProductRepository
+	product domain.Product
+}
+
+func (r *pricedProduct) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	if r.product.ID.Hex() != id {
+		return nil, domain.ErrProductNotFound
+	}
+	product := r.product
+	return &product, nil
+}
+
+type knownCategories struct {
+	domain.CategoryRepository
+	categories []domain.Category
+}
+
+func (r knownCategories) FindByID(ctx context.Context, id string) (*domain.Category, error) {
+	for _, category := range r.categories {
+		if category.ID.Hex() == id {
+			return &category, nil
+		}
+	}
+	return nil, domain.ErrCategoryNotFound
+}
+
+type baseCurrencyCart struct{ domain.CartRepository }
+
+func (baseCurrencyCart) FindByID(ctx context.Context, id string) (*domain.Cart, error) {
+	return &domain.Cart{Currency: domain.BaseCurrency}, nil
+}
+
+type sameRate struct{ domain.ExchangeRateUseCase }
+
+func (sameRate) Convert(ctx context.Context, amount domain.Money, from, to domain.Currency) (domain.Money, error) {
+	return amount, nil
+}
+
+func newCartItemFixture() (*storedCartItems, domain.CartItemUseCase) {
+	mugs := domain.Category{ID: primitive.NewObjectID(), Name: "Mugs"}
+	product := domain.Product{ID: primitive.NewObjectID(), Name: "Mug", Price: 150_00, Stock: 10, CategoryID: mugs.ID}
+	items := &storedCartItems{item: domain.CartItem{
+		ID: primitive.NewObjectID(), CartId: primitive.NewObjectID(), ProductId: product.ID,
+		CategoryID: mugs.ID, Category: mugs.Name, Quantity: 1, UnitPrice: 150_00, TotalPrice: 150_00, Currency: domain.BaseCurrency,
+	}}
+	return items, NewCartItemUseCase(items, &pricedProduct{product: product}, knownCategories{categories: []domain.Category{mugs}}, baseCurrencyCart{}, sameRate{})
+}
+
+func TestCartItemUseCase_Update_RepricesWithoutProductID(t *testing.T) {
+	items, uc := newCartItemFixture()
+
+	update := &domain.CartItem{ID: items.item.ID, Quantity: 2, UnitPrice: 1, TotalPrice: 2, Currency: "USD"}
+	require.NoError(t, uc.Update(context.Background(), update))
+
+	require.Len(t, items.updated, 1)
+	saved := items.updated[0]
+	assert.Equal(t, items.item.ProductId, saved.ProductId)
+	assert.Equal(t, items.item.CartId, saved.CartId)
+	assert.Equal(t, domain.Money(150_00), saved.UnitPrice)
+	assert.Equal(t, domain.Money(300_00), saved.TotalPrice)
+	assert.Equal(t, domain.BaseCurrency, saved.Currency)
+}
+
+func TestCartItemUseCase_Update_RejectsProductChange(t *testing.T) {
+	items, uc := newCartItemFixture()
+
+	update := &domain.CartItem{ID: items.item.ID, ProductId: primitive.NewObjectID(), Quantity: 1}
+	assert.Equal(t, domain.ErrCartItemProductChanged, uc.Update(context.Background(), update))
+
+	variantID := primitive.NewObjectID()
+	update = &domain.CartItem{ID: items.item.ID, VariantId: &variantID, Quantity: 1}
+	assert.Equal(t, domain.ErrCartItemProductChanged, uc.Update(context.Background(), update))
+
+	assert.Empty(t, items.updated)
+}
+
+func TestCartItemUseCase_Create_TakesCategoryFromProduct(t *testing.T) {
+	items, uc := newCartItemFixture()
+
+	cartItem := &domain.CartItem{CartId: items.item.CartId, ProductId: items.item.ProductId, Quantity: 1, Category: "Clothing", CategoryID: primitive.NewObjectID()}
+	require.NoError(t, uc.Create(context.Background(), cartItem))
+
+	require.Len(t, items.created, 1)
+	assert.Equal(t, "Mugs", items.created[0].Category)
+	assert.Equal(t, items.item.CategoryID, items.created[0].CategoryID)
+}
@@ -12,16 +12,41 @@ import (
 )
 
 type cartUseCase struct {
-	cartRepo domain.CartRepository
+	cartRepo            domain.CartRepository
+	exchangeRateUseCase domain.ExchangeRateUseCase
+	taxUseCase          domain.TaxUseCase
 }
 
-func NewCartUseCase(cr domain.CartRepository) domain.CartUseCase {
+func NewCartUseCase(cr domain.CartRepository, eru domain.ExchangeRateUseCase, tu domain.TaxUseCase) domain.CartUseCase {
 	return &cartUseCase{
-		cartRepo: cr,
+		cartRepo:            cr,
+		exchangeRateUseCase: eru,
+		taxUseCase:          tu,
 	}
 }
 
+// Create prices the cart in the base currency unless it asks for another
+// one the exchange-rate table knows. A tax region, if given, must be
+// configured.
 func (uc *cartUseCase) Create(ctx context.Context, cart *domain.Cart) error {
+	cart.Currency = domain.Currency(strings.ToUpper(string(cart.Currency))).OrBase()
+	if !cart.Currency.Valid() {
+		return domain.ErrInvalidCurrency
+	}
+	supported, err := uc.exchangeRateUseCase.Supported(ctx, cart.Currency)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return domain.ErrUnsupportedCurrency
+	}
+	if cart.TaxRegion != "" {
+		region, err := uc.taxUseCase.Region(ctx, cart.TaxRegion)
+		if err != nil {
+			return err
+		}
+		cart.TaxRegion = region.Code
+	}
 	return uc.cartRepo.Create(cart)
 }
 
@@ -55,11 +80,19 @@ func (uc *cartUseCase) GetByID(ctx context.Context, id string) (*domain.Cart, er
 	return cart, nil
 }
 
-func (uc *cartUseCase) GetAll(ctx context.Context) ([]domain.Cart, error) {
-	return uc.cartRepo.FindAll(ctx)
+func (uc *cartUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Cart, *domain.Pagination, error) {
+	return uc.cartRepo.FindAll(ctx, query)
 }
 
+// Update keeps the cart's currency, since its lines are already priced in it,
+// and its tax region.
 func (uc *cartUseCase) Update(ctx context.Context, cart *domain.Cart) error {
+	existing, err := uc.GetByID(ctx, cart.ID.Hex())
+	if err != nil {
+		return err
+	}
+	cart.Currency = existing.Currency
+	cart.TaxRegion = existing.TaxRegion
 	return uc.cartRepo.Update(ctx, cart)
 }
 
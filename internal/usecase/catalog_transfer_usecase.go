@@ -0,0 +1,479 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxImportErrors = 1000
+	exportFlushRows = 100
+)
+
+var (
+	productColumns  = []string{"sku", "slug", "name", "description", "content", "price", "image", "category_id", "stock"}
+	categoryColumns = []string{"id", "slug", "name", "description", "parent_id", "path", "depth"}
+)
+
+type productRecord struct {
+	SKU         string       `json:"sku"`
+	Slug        string       `json:"slug,omitempty"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Content     string       `json:"content"`
+	Price       domain.Money `json:"price"`
+	Image       string       `json:"image"`
+	CategoryID  string       `json:"category_id"`
+	Stock       int          `json:"stock"`
+}
+
+type categoryRecord struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ParentID    string `json:"parent_id"`
+	Path        string `json:"path"`
+	Depth       int    `json:"depth"`
+}
+
+type catalogTransferUseCase struct {
+	productRepo  domain.ProductRepository
+	categoryRepo domain.CategoryRepository
+	searchIndex  domain.SearchIndex
+	prices       *priceApplier
+	inventory    domain.InventoryUseCase
+	validator    *validator.CustomValidator
+}
+
+func NewCatalogTransferUseCase(pr domain.ProductRepository, cr domain.CategoryRepository, si domain.SearchIndex, pcr domain.PriceChangeRepository, ep domain.EventPublisher, inv domain.InventoryUseCase, v *validator.CustomValidator) domain.CatalogTransferUseCase {
+	return &catalogTransferUseCase{
+		productRepo:  pr,
+		categoryRepo: cr,
+		searchIndex:  si,
+		prices:       &priceApplier{productRepo: pr, priceRepo: pcr, searchIndex: si, publisher: ep},
+		inventory:    inv,
+		validator:    v,
+	}
+}
+
+type importState struct {
+	dryRun     bool
+	seen       map[string]int
+	categories map[primitive.ObjectID]bool
+}
+
+func (uc *catalogTransferUseCase) ImportProducts(ctx context.Context, format domain.TransferFormat, r io.Reader, dryRun bool) (*domain.ImportResult, error) {
+	result := &domain.ImportResult{DryRun: dryRun, Errors: []domain.ImportRowError{}}
+	state := &importState{
+		dryRun:     dryRun,
+		seen:       make(map[string]int),
+		categories: make(map[primitive.ObjectID]bool),
+	}
+
+	err := readProductRecords(format, r, func(row int, record *productRecord, rowErr error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result.Total++
+		created := false
+		if rowErr == nil {
+			created, rowErr = uc.importProduct(ctx, state, row, record)
+		}
+
+		switch {
+		case rowErr != nil:
+			result.Failed++
+			if len(result.Errors) < maxImportErrors {
+				rowError := domain.ImportRowError{Row: row, Message: rowErr.Error()}
+				if record != nil {
+					rowError.Key = record.key()
+				}
+				result.Errors = append(result.Errors, rowError)
+			}
+		case created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *catalogTransferUseCase) importProduct(ctx context.Context, state *importState, row int, record *productRecord) (bool, error) {
+	key := record.key()
+	if first, ok := state.seen[key]; ok {
+		return false, fmt.Errorf("duplicate of row %d", first)
+	}
+
+	product := record.toProduct()
+	if err := uc.validator.Validate(product); err != nil {
+		return false, err
+	}
+	if err := uc.checkCategory(ctx, state, product.CategoryID, record.CategoryID); err != nil {
+		return false, err
+	}
+
+	existing, err := uc.findExisting(ctx, record)
+	if err != nil {
+		return false, err
+	}
+	state.seen[key] = row
+
+	if state.dryRun {
+		return existing == nil, nil
+	}
+
+	if existing == nil {
+		if err := uc.assignSlug(ctx, product, nil); err != nil {
+			return false, err
+		}
+		if err := uc.productRepo.Create(ctx, product); err != nil {
+			return false, err
+		}
+		return true, uc.searchIndex.Index(ctx, product)
+	}
+
+	existing.SKU = product.SKU
+	existing.Name = product.Name
+	existing.Description = product.Description
+	existing.Content = product.Content
+	existing.Image = product.Image
+	existing.CategoryID = product.CategoryID
+	if err := uc.assignSlug(ctx, product, existing); err != nil {
+		return false, err
+	}
+	existing.Slug, existing.PreviousSlugs = product.Slug, product.PreviousSlugs
+	if err := uc.productRepo.Update(ctx, existing); err != nil {
+		return false, err
+	}
+	// Stock changes go through the ledger like any other count correction.
+	// Products with variants keep their stock per variant, which the import
+	// doesn't carry.
+	if len(existing.Variants) == 0 && product.Stock != existing.Stock {
+		movement, err := uc.inventory.Adjust(ctx, existing.ID.Hex(), domain.Actor{}, domain.StockAdjustment{
+			Type:     domain.StockMovementCorrection,
+			Quantity: product.Stock,
+			Reason:   "catalogue import",
+		})
+		if err != nil {
+			return false, err
+		}
+		existing.Stock = movement.StockAfter
+	}
+	if product.Price != existing.Price {
+		_, err := uc.prices.record(ctx, existing, product.Price, "catalogue import", domain.Actor{})
+		return false, err
+	}
+	return false, uc.searchIndex.Index(ctx, existing)
+}
+
+func (uc *catalogTransferUseCase) assignSlug(ctx context.Context, product, existing *domain.Product) error {
+	s := slugged{name: product.Name, fallback: "product", requested: product.Slug}
+	if existing != nil {
+		s.id, s.current, s.previous = existing.ID, existing.Slug, existing.PreviousSlugs
+	}
+
+	var err error
+	product.Slug, product.PreviousSlugs, err = s.resolve(ctx, uc.productRepo.SlugTaken)
+	return err
+}
+
+func (uc *catalogTransferUseCase) checkCategory(ctx context.Context, state *importState, id primitive.ObjectID, raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if id.IsZero() {
+		return domain.ErrInvalidCategoryID
+	}
+
+	known, checked := state.categories[id]
+	if !checked {
+		_, err := uc.categoryRepo.FindByID(ctx, id.Hex())
+		known = err == nil
+		state.categories[id] = known
+	}
+	if !known {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (uc *catalogTransferUseCase) findExisting(ctx context.Context, record *productRecord) (*domain.Product, error) {
+	var (
+		product *domain.Product
+		err     error
+	)
+	if record.SKU != "" {
+		product, err = uc.productRepo.FindBySKU(ctx, record.SKU)
+	} else {
+		product, err = uc.productRepo.FindByName(ctx, record.Name)
+	}
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return nil, nil
+	}
+	return product, err
+}
+
+func (uc *catalogTransferUseCase) ExportProducts(ctx context.Context, format domain.TransferFormat, w io.Writer) error {
+	out, err := newRecordWriter(format, w, productColumns)
+	if err != nil {
+		return err
+	}
+
+	err = uc.productRepo.Each(ctx, func(product *domain.Product) error {
+		record := productRecord{
+			SKU:         product.SKU,
+			Slug:        product.Slug,
+			Name:        product.Name,
+			Description: product.Description,
+			Content:     product.Content,
+			Price:       product.Price,
+			Image:       product.Image,
+			Stock:       product.Stock,
+		}
+		if !product.CategoryID.IsZero() {
+			record.CategoryID = product.CategoryID.Hex()
+		}
+		return out.write(record, []string{
+			record.SKU,
+			record.Slug,
+			record.Name,
+			record.Description,
+			record.Content,
+			record.Price.String(),
+			record.Image,
+			record.CategoryID,
+			strconv.Itoa(record.Stock),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+func (uc *catalogTransferUseCase) ExportCategories(ctx context.Context, format domain.TransferFormat, w io.Writer) error {
+	out, err := newRecordWriter(format, w, categoryColumns)
+	if err != nil {
+		return err
+	}
+
+	err = uc.categoryRepo.Each(ctx, func(category *domain.Category) error {
+		record := categoryRecord{
+			ID:          category.ID.Hex(),
+			Slug:        category.Slug,
+			Name:        category.Name,
+			Description: category.Description,
+			Path:        category.Path,
+			Depth:       category.Depth,
+		}
+		if category.ParentID != nil {
+			record.ParentID = category.ParentID.Hex()
+		}
+		return out.write(record, []string{
+			record.ID,
+			record.Slug,
+			record.Name,
+			record.Description,
+			record.ParentID,
+			record.Path,
+			strconv.Itoa(record.Depth),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+func (r *productRecord) key() string {
+	if r.SKU != "" {
+		return "sku:" + r.SKU
+	}
+	return "name:" + r.Name
+}
+
+func (r *productRecord) toProduct() *domain.Product {
+	product := &domain.Product{
+		SKU:         r.SKU,
+		Slug:        r.Slug,
+		Name:        r.Name,
+		Description: r.Description,
+		Content:     r.Content,
+		Price:       r.Price,
+		Image:       r.Image,
+		Stock:       r.Stock,
+	}
+	if id, err := primitive.ObjectIDFromHex(r.CategoryID); err == nil {
+		product.CategoryID = id
+	}
+	return product
+}
+
+// readProductRecords streams records to fn one at a time. Problems confined
+// to a single row are passed to fn as rowErr; only unreadable input aborts.
+func readProductRecords(format domain.TransferFormat, r io.Reader, fn func(row int, record *productRecord, rowErr error) error) error {
+	switch format {
+	case domain.TransferFormatCSV:
+		return readProductCSV(r, fn)
+	case domain.TransferFormatJSONL:
+		return readProductJSONL(r, fn)
+	}
+	return domain.ErrUnsupportedFormat
+}
+
+func readProductCSV(r io.Reader, fn func(int, *productRecord, error) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return domain.ErrInvalidImportFile
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return domain.ErrInvalidImportFile
+	}
+
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err := fn(row, nil, parseErr.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		record := &productRecord{
+			SKU:         field("sku"),
+			Slug:        field("slug"),
+			Name:        field("name"),
+			Description: field("description"),
+			Content:     field("content"),
+			Image:       field("image"),
+			CategoryID:  field("category_id"),
+		}
+
+		var rowErr error
+		if price := field("price"); price != "" {
+			if record.Price, err = domain.ParseMoney(price); err != nil {
+				rowErr = fmt.Errorf("invalid price %q", price)
+			}
+		}
+		if stock := field("stock"); stock != "" && rowErr == nil {
+			if record.Stock, err = strconv.Atoi(stock); err != nil {
+				rowErr = fmt.Errorf("invalid stock %q", stock)
+			}
+		}
+
+		if err := fn(row, record, rowErr); err != nil {
+			return err
+		}
+	}
+}
+
+func readProductJSONL(r io.Reader, fn func(int, *productRecord, error) error) error {
+	reader := bufio.NewReader(r)
+	for row := 1; ; row++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			var record productRecord
+			if decodeErr := json.Unmarshal(trimmed, &record); decodeErr != nil {
+				if err := fn(row, nil, fmt.Errorf("invalid JSON: %v", decodeErr)); err != nil {
+					return err
+				}
+			} else {
+				record.SKU = strings.TrimSpace(record.SKU)
+				record.Name = strings.TrimSpace(record.Name)
+				if err := fn(row, &record, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+type recordWriter struct {
+	csv  *csv.Writer
+	json *json.Encoder
+	rows int
+}
+
+func newRecordWriter(format domain.TransferFormat, w io.Writer, header []string) (*recordWriter, error) {
+	switch format {
+	case domain.TransferFormatCSV:
+		out := &recordWriter{csv: csv.NewWriter(w)}
+		return out, out.csv.Write(header)
+	case domain.TransferFormatJSONL:
+		return &recordWriter{json: json.NewEncoder(w)}, nil
+	}
+	return nil, domain.ErrUnsupportedFormat
+}
+
+func (w *recordWriter) write(record interface{}, fields []string) error {
+	if w.json != nil {
+		return w.json.Encode(record)
+	}
+
+	if err := w.csv.Write(fields); err != nil {
+		return err
+	}
+	w.rows++
+	if w.rows%exportFlushRows == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *recordWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/validator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type readRow struct {
+	row    int
+	record *productRecord
+	err    error
+}
+
+func collectProductRecords(t *testing.T, format domain.TransferFormat, input string) []readRow {
+	var rows []readRow
+	err := readProductRecords(format, strings.NewReader(input), func(row int, record *productRecord, rowErr error) error {
+		rows = append(rows, readRow{row: row, record: record, err: rowErr})
+		return nil
+	})
+	assert.NoError(t, err)
+	return rows
+}
+
+func TestReadProductRecords_CSV(t *testing.T) {
+	input := "\ufeffName,SKU,Price,Stock,Description\n" +
+		"Mug,MUG-1,149.5,10,Ceramic mug\n" +
+		"Plate,,abc,3,Dinner plate\n" +
+		"\"Bowl, large\",BWL-1,89,,Soup bowl\n"
+
+	rows := collectProductRecords(t, domain.TransferFormatCSV, input)
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, 2, rows[0].row)
+	assert.Equal(t, &productRecord{SKU: "MUG-1", Name: "Mug", Price: 149_50, Stock: 10, Description: "Ceramic mug"}, rows[0].record)
+	assert.EqualError(t, rows[1].err, `invalid price "abc"`)
+	assert.Equal(t, "Bowl, large", rows[2].record.Name)
+	assert.Equal(t, 0, rows[2].record.Stock)
+	assert.Equal(t, "sku:BWL-1", rows[2].record.key())
+}
+
+func TestReadProductRecords_CSVMissingNameColumn(t *testing.T) {
+	err := readProductRecords(domain.TransferFormatCSV, strings.NewReader("sku,price\nA,1\n"), func(int, *productRecord, error) error {
+		return nil
+	})
+
+	assert.Equal(t, domain.ErrInvalidImportFile, err)
+}
+
+func TestReadProductRecords_JSONL(t *testing.T) {
+	input := `{"name":"Mug","price":149.5,"stock":10}` + "\n\n" +
+		`{"name":` + "\n" +
+		`{"name":"Plate","sku":" PLT-1 "}`
+
+	rows := collectProductRecords(t, domain.TransferFormatJSONL, input)
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "name:Mug", rows[0].record.key())
+	assert.Equal(t, 3, rows[1].row)
+	assert.Error(t, rows[1].err)
+	assert.Equal(t, 4, rows[2].row)
+	assert.Equal(t, "PLT-1", rows[2].record.SKU)
+}
+
+func TestRecordWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	out, err := newRecordWriter(domain.TransferFormatCSV, &buf, []string{"name", "price"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, out.write(nil, []string{"Bowl, large", "89"}))
+	assert.NoError(t, out.flush())
+	assert.Equal(t, "name,price\n\"Bowl, large\",89\n", buf.String())
+}
+
+// catalogProducts holds one product found by SKU and records what Update saw.
+type catalogProducts struct {
+	domain.ProductRepository
+	product *domain.Product
+	updated []domain.Product
+}
+
+func (r *catalogProducts) FindBySKU(ctx context.Context, sku string) (*domain.Product, error) {
+	if r.product.SKU != sku {
+		return nil, domain.ErrProductNotFound
+	}
+	product := *r.product
+	return &product, nil
+}
+
+func (r *catalogProducts) Update(ctx context.Context, product *domain.Product) error {
+	r.updated = append(r.updated, *product)
+	return nil
+}
+
+type reindex struct{ domain.SearchIndex }
+
+func (reindex) Index(ctx context.Context, product *domain.Product) error { return nil }
+
+// ledger records adjustments and applies corrections to the counted level.
+type ledger struct {
+	domain.InventoryUseCase
+	adjustments []domain.StockAdjustment
+	stock       int
+}
+
+func (l *ledger) Adjust(ctx context.Context, productID string, actor domain.Actor, adjustment domain.StockAdjustment) (*domain.StockMovement, error) {
+	l.adjustments = append(l.adjustments, adjustment)
+	movement := &domain.StockMovement{Type: adjustment.Type, StockBefore: l.stock, StockAfter: adjustment.Quantity}
+	movement.Quantity = movement.StockAfter - movement.StockBefore
+	l.stock = adjustment.Quantity
+	return movement, nil
+}
+
+func TestImportProducts_RecordsStockChangesInLedger(t *testing.T) {
+	products := &catalogProducts{product: &domain.Product{
+		ID: primitive.NewObjectID(), SKU: "MUG-1", Slug: "mug", Name: "Mug",
+		Description: "Ceramic mug", Content: "ceramic", Price: 149_50, Image: "mug.jpg", Stock: 10,
+	}}
+	inventory := &ledger{stock: 10}
+	uc := NewCatalogTransferUseCase(products, nil, reindex{}, nil, nil, inventory, validator.NewValidator())
+
+	input := "sku,name,description,content,price,image,stock\n" +
+		"MUG-1,Mug,Ceramic mug,ceramic,149.5,mug.jpg,4\n"
+	result, err := uc.ImportProducts(context.Background(), domain.TransferFormatCSV, strings.NewReader(input), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Updated)
+	require.Len(t, products.updated, 1)
+	assert.Equal(t, 10, products.updated[0].Stock, "stock must not be written around the ledger")
+	assert.Equal(t, []domain.StockAdjustment{
+		{Type: domain.StockMovementCorrection, Quantity: 4, Reason: "catalogue import"},
+	}, inventory.adjustments)
+}
+
+func TestImportProducts_UnchangedStockSkipsLedger(t *testing.T) {
+	products := &catalogProducts{product: &domain.Product{
+		ID: primitive.NewObjectID(), SKU: "MUG-1", Slug: "mug", Name: "Mug",
+		Description: "Ceramic mug", Content: "ceramic", Price: 149_50, Image: "mug.jpg", Stock: 10,
+	}}
+	inventory := &ledger{stock: 10}
+	uc := NewCatalogTransferUseCase(products, nil, reindex{}, nil, nil, inventory, validator.NewValidator())
+
+	input := "sku,name,description,content,price,image,stock\n" +
+		"MUG-1,Mug,Ceramic mug,ceramic,149.5,mug.jpg,10\n"
+	_, err := uc.ImportProducts(context.Background(), domain.TransferFormatCSV, strings.NewReader(input), false)
+
+	require.NoError(t, err)
+	assert.Empty(t, inventory.adjustments)
+}
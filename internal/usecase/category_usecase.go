@@ -22,6 +22,13 @@ func NewCategoryUseCase(cr domain.CategoryRepository) domain.CategoryUseCase {
 }
 
 func (uc *categoryUseCase) Create(ctx context.Context, category *domain.Category) error {
+	category.DeletedAt = nil
+	if err := uc.placeUnderParent(ctx, category); err != nil {
+		return err
+	}
+	if err := uc.assignSlug(ctx, category, nil); err != nil {
+		return err
+	}
 	return uc.categoryRepo.Create(ctx, category)
 }
 
@@ -40,12 +47,165 @@ func (uc *categoryUseCase) GetByID(ctx context.Context, id string) (*domain.Cate
 	return category, nil
 }
 
-func (uc *categoryUseCase) GetAll(ctx context.Context) ([]domain.Category, error) {
-	return uc.categoryRepo.FindAll(ctx)
+func (uc *categoryUseCase) GetBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	return uc.categoryRepo.FindBySlug(ctx, slug)
+}
+
+func (uc *categoryUseCase) assignSlug(ctx context.Context, category, existing *domain.Category) error {
+	s := slugged{id: category.ID, name: category.Name, fallback: "category", requested: category.Slug}
+	if existing != nil {
+		s.current, s.previous = existing.Slug, existing.PreviousSlugs
+	}
+
+	var err error
+	category.Slug, category.PreviousSlugs, err = s.resolve(ctx, uc.categoryRepo.SlugTaken)
+	return err
+}
+
+// EnsureSlugs gives every category created before slugs existed one derived
+// from its name.
+func (uc *categoryUseCase) EnsureSlugs(ctx context.Context) (int, error) {
+	var missing []domain.Category
+	err := uc.categoryRepo.Each(ctx, func(category *domain.Category) error {
+		if category.Slug == "" {
+			missing = append(missing, *category)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range missing {
+		category := &missing[i]
+		if err := uc.assignSlug(ctx, category, nil); err != nil {
+			return i, err
+		}
+		if err := uc.categoryRepo.Update(ctx, category); err != nil {
+			return i, err
+		}
+	}
+	return len(missing), nil
+}
+
+// EnsurePaths places every category created before the hierarchy existed at
+// the root, so it shows up in the tree.
+func (uc *categoryUseCase) EnsurePaths(ctx context.Context) (int, error) {
+	var missing []domain.Category
+	err := uc.categoryRepo.Each(ctx, func(category *domain.Category) error {
+		if category.Path == "" {
+			missing = append(missing, *category)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range missing {
+		category := &missing[i]
+		if err := uc.placeUnderParent(ctx, category); err != nil {
+			return i, err
+		}
+		if err := uc.categoryRepo.Update(ctx, category); err != nil {
+			return i, err
+		}
+	}
+	return len(missing), nil
+}
+
+func (uc *categoryUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Category, *domain.Pagination, error) {
+	return uc.categoryRepo.FindAll(ctx, query)
+}
+
+func (uc *categoryUseCase) GetTree(ctx context.Context) ([]*domain.CategoryNode, error) {
+	categories, err := uc.categoryRepo.FindByPathPrefix(ctx, domain.CategoryRootPath)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories, nil), nil
+}
+
+func (uc *categoryUseCase) GetSubtree(ctx context.Context, id string) (*domain.CategoryNode, error) {
+	category, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := uc.categoryRepo.FindByPathPrefix(ctx, category.SubtreePath())
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CategoryNode{
+		Category: *category,
+		Children: buildCategoryTree(descendants, &category.ID),
+	}, nil
+}
+
+func (uc *categoryUseCase) GetBreadcrumbs(ctx context.Context, id string) ([]domain.Category, error) {
+	category, err := uc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestorIDs := category.AncestorIDs()
+	if len(ancestorIDs) == 0 {
+		return []domain.Category{*category}, nil
+	}
+
+	ancestors, err := uc.categoryRepo.FindByIDs(ctx, ancestorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[primitive.ObjectID]domain.Category, len(ancestors))
+	for _, ancestor := range ancestors {
+		byID[ancestor.ID] = ancestor
+	}
+
+	breadcrumbs := make([]domain.Category, 0, len(ancestorIDs)+1)
+	for _, ancestorID := range ancestorIDs {
+		if ancestor, ok := byID[ancestorID]; ok {
+			breadcrumbs = append(breadcrumbs, ancestor)
+		}
+	}
+	return append(breadcrumbs, *category), nil
 }
 
 func (uc *categoryUseCase) Update(ctx context.Context, category *domain.Category) error {
-	return uc.categoryRepo.Update(ctx, category)
+	existing, err := uc.GetByID(ctx, category.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	category.DeletedAt = nil
+	if sameParent(existing.ParentID, category.ParentID) {
+		category.Path = existing.Path
+		category.Depth = existing.Depth
+		if err := uc.assignSlug(ctx, category, existing); err != nil {
+			return err
+		}
+		return uc.categoryRepo.Update(ctx, category)
+	}
+
+	if category.ParentID != nil && *category.ParentID == category.ID {
+		return domain.ErrCategoryCycle
+	}
+	if err := uc.placeUnderParent(ctx, category); err != nil {
+		return err
+	}
+	if strings.HasPrefix(category.Path, existing.SubtreePath()) {
+		return domain.ErrCategoryCycle
+	}
+	if err := uc.assignSlug(ctx, category, existing); err != nil {
+		return err
+	}
+
+	if err := uc.categoryRepo.Update(ctx, category); err != nil {
+		return err
+	}
+	return uc.moveDescendants(ctx, existing, category)
 }
 
 func (uc *categoryUseCase) Delete(ctx context.Context, id string) error {
@@ -53,5 +213,107 @@ func (uc *categoryUseCase) Delete(ctx context.Context, id string) error {
 		return domain.ErrInvalidCategoryID
 	}
 
+	hasChildren, err := uc.categoryRepo.HasChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return domain.ErrCategoryHasChildren
+	}
+
 	return uc.categoryRepo.Delete(ctx, id)
 }
+
+// Restore brings back an archived category together with any archived
+// ancestors, so the restored category is reachable from the tree again.
+func (uc *categoryUseCase) Restore(ctx context.Context, id string) (*domain.Category, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, domain.ErrInvalidCategoryID
+	}
+
+	category, err := uc.categoryRepo.FindArchivedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ancestorID := range category.AncestorIDs() {
+		err := uc.categoryRepo.Restore(ctx, ancestorID.Hex())
+		if err != nil && !errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil, err
+		}
+	}
+	if err := uc.categoryRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	category.DeletedAt = nil
+	return category, nil
+}
+
+func (uc *categoryUseCase) GetArchived(ctx context.Context, query domain.ListQuery) ([]domain.Category, *domain.Pagination, error) {
+	return uc.categoryRepo.FindArchived(ctx, query)
+}
+
+func (uc *categoryUseCase) placeUnderParent(ctx context.Context, category *domain.Category) error {
+	if category.ParentID == nil {
+		category.Path = domain.CategoryRootPath
+		category.Depth = 0
+		return nil
+	}
+
+	parent, err := uc.categoryRepo.FindByID(ctx, category.ParentID.Hex())
+	if err != nil {
+		return domain.ErrParentCategoryNotFound
+	}
+
+	category.Path = parent.SubtreePath()
+	category.Depth = parent.Depth + 1
+	return nil
+}
+
+func (uc *categoryUseCase) moveDescendants(ctx context.Context, from, to *domain.Category) error {
+	oldPrefix := from.SubtreePath()
+	descendants, err := uc.categoryRepo.FindByPathPrefix(ctx, oldPrefix)
+	if err != nil {
+		return err
+	}
+
+	newPrefix := to.SubtreePath()
+	depthDelta := to.Depth - from.Depth
+	for i := range descendants {
+		descendant := &descendants[i]
+		descendant.Path = newPrefix + strings.TrimPrefix(descendant.Path, oldPrefix)
+		descendant.Depth += depthDelta
+		if err := uc.categoryRepo.Update(ctx, descendant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameParent(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func buildCategoryTree(categories []domain.Category, rootID *primitive.ObjectID) []*domain.CategoryNode {
+	nodes := make(map[primitive.ObjectID]*domain.CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &domain.CategoryNode{Category: category, Children: []*domain.CategoryNode{}}
+	}
+
+	roots := []*domain.CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID == nil || sameParent(category.ParentID, rootID) {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[*category.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	return roots
+}
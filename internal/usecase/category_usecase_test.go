@@ -4,6 +4,7 @@ import (
 	"context"
 	"play-to-win-api/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,16 +25,71 @@ func (m *MockCategoryRepository) FindByID(ctx context.Context, id string) (*doma
 	return args.Get(0).(*domain.Category), args.Error(1)
 }
 
-func (m *MockCategoryRepository) FindAll(ctx context.Context) ([]domain.Category, error) {
-	args := m.Called(ctx)
+func (m *MockCategoryRepository) FindByName(ctx context.Context, name string) (*domain.Category, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	args := m.Called(ctx, slug)
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) SlugTaken(ctx context.Context, slug string, exclude primitive.ObjectID) (bool, error) {
+	args := m.Called(ctx, slug, exclude)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Category, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindByPathPrefix(ctx context.Context, prefix string) ([]domain.Category, error) {
+	args := m.Called(ctx, prefix)
 	return args.Get(0).([]domain.Category), args.Error(1)
 }
 
+func (m *MockCategoryRepository) HasChildren(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindAll(ctx context.Context, query domain.ListQuery) ([]domain.Category, *domain.Pagination, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]domain.Category), args.Get(1).(*domain.Pagination), args.Error(2)
+}
+
 func (m *MockCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
 	args := m.Called(ctx, category)
 	return args.Error(0)
 }
 
+func (m *MockCategoryRepository) Each(ctx context.Context, fn func(*domain.Category) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Restore(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) FindArchived(ctx context.Context, query domain.ListQuery) ([]domain.Category, *domain.Pagination, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]domain.Category), args.Get(1).(*domain.Pagination), args.Error(2)
+}
+
+func (m *MockCategoryRepository) FindArchivedByID(ctx context.Context, id string) (*domain.Category, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) PurgeArchived(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockCategoryRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -44,10 +100,13 @@ func TestCategoryUseCase_Create(t *testing.T) {
 	uc := NewCategoryUseCase(mockRepo)
 
 	category := &domain.Category{ID: primitive.NewObjectID(), Name: "Test Category"}
+	mockRepo.On("SlugTaken", mock.Anything, "test-category", category.ID).Return(true, nil)
+	mockRepo.On("SlugTaken", mock.Anything, "test-category-2", category.ID).Return(false, nil)
 	mockRepo.On("Create", mock.Anything, category).Return(nil)
 
 	err := uc.Create(context.Background(), category)
 	assert.NoError(t, err)
+	assert.Equal(t, "test-category-2", category.Slug)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -97,11 +156,14 @@ func TestCategoryUseCase_GetAll(t *testing.T) {
 		{ID: primitive.NewObjectID(), Name: "Category 1"},
 		{ID: primitive.NewObjectID(), Name: "Category 2"},
 	}
-	mockRepo.On("FindAll", mock.Anything).Return(expectedCategories, nil)
+	expectedPagination := &domain.Pagination{Page: 1, Limit: domain.DefaultListLimit, Total: 2, TotalPages: 1}
+	query := domain.ListQuery{Sort: []domain.SortField{{Field: "name"}}}
+	mockRepo.On("FindAll", mock.Anything, query).Return(expectedCategories, expectedPagination, nil)
 
-	categories, err := uc.GetAll(context.Background())
+	categories, pagination, err := uc.GetAll(context.Background(), query)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCategories, categories)
+	assert.Equal(t, expectedPagination, pagination)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -110,18 +172,137 @@ func TestCategoryUseCase_Update(t *testing.T) {
 	uc := NewCategoryUseCase(mockRepo)
 
 	category := &domain.Category{ID: primitive.NewObjectID(), Name: "Updated Category"}
+	existing := &domain.Category{ID: category.ID, Name: "Category", Slug: "category", Path: domain.CategoryRootPath}
+	mockRepo.On("FindByID", mock.Anything, category.ID.Hex()).Return(existing, nil)
 	mockRepo.On("Update", mock.Anything, category).Return(nil)
 
 	err := uc.Update(context.Background(), category)
 	assert.NoError(t, err)
+	assert.Equal(t, domain.CategoryRootPath, category.Path)
+	assert.Equal(t, "category", category.Slug)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCategoryUseCase_Update_ChangedSlugRedirectsFromOld(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	existing := &domain.Category{
+		ID:            primitive.NewObjectID(),
+		Name:          "Shoes",
+		Slug:          "shoes",
+		PreviousSlugs: []string{"sneakers"},
+		Path:          domain.CategoryRootPath,
+	}
+	category := &domain.Category{ID: existing.ID, Name: "Shoes", Slug: "Sneakers"}
+	mockRepo.On("FindByID", mock.Anything, existing.ID.Hex()).Return(existing, nil)
+	mockRepo.On("SlugTaken", mock.Anything, "sneakers", existing.ID).Return(false, nil)
+	mockRepo.On("Update", mock.Anything, category).Return(nil)
+
+	err := uc.Update(context.Background(), category)
+	assert.NoError(t, err)
+	assert.Equal(t, "sneakers", category.Slug)
+	assert.Equal(t, []string{"shoes"}, category.PreviousSlugs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryUseCase_Update_MoveUnderDescendant(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	root := &domain.Category{ID: primitive.NewObjectID(), Name: "Clothing", Path: domain.CategoryRootPath}
+	child := &domain.Category{ID: primitive.NewObjectID(), Name: "Men", ParentID: &root.ID, Path: root.SubtreePath(), Depth: 1}
+	mockRepo.On("FindByID", mock.Anything, root.ID.Hex()).Return(root, nil)
+	mockRepo.On("FindByID", mock.Anything, child.ID.Hex()).Return(child, nil)
+
+	err := uc.Update(context.Background(), &domain.Category{ID: root.ID, Name: "Clothing", ParentID: &child.ID})
+	assert.ErrorIs(t, err, domain.ErrCategoryCycle)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCategoryUseCase_Update_MovesDescendants(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	clothing := &domain.Category{ID: primitive.NewObjectID(), Name: "Clothing", Path: domain.CategoryRootPath}
+	men := &domain.Category{ID: primitive.NewObjectID(), Name: "Men", Path: domain.CategoryRootPath}
+	shirts := domain.Category{ID: primitive.NewObjectID(), Name: "Shirts", ParentID: &men.ID, Path: men.SubtreePath(), Depth: 1}
+	mockRepo.On("FindByID", mock.Anything, men.ID.Hex()).Return(men, nil)
+	mockRepo.On("FindByID", mock.Anything, clothing.ID.Hex()).Return(clothing, nil)
+	mockRepo.On("FindByPathPrefix", mock.Anything, men.SubtreePath()).Return([]domain.Category{shirts}, nil)
+	mockRepo.On("SlugTaken", mock.Anything, "men", men.ID).Return(false, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	moved := &domain.Category{ID: men.ID, Name: "Men", ParentID: &clothing.ID}
+	err := uc.Update(context.Background(), moved)
+	assert.NoError(t, err)
+	assert.Equal(t, clothing.SubtreePath(), moved.Path)
+	assert.Equal(t, 1, moved.Depth)
+	mockRepo.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+		return c.ID == shirts.ID && c.Path == moved.SubtreePath() && c.Depth == 2
+	}))
+}
+
+func TestCategoryUseCase_GetTree(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	clothing := domain.Category{ID: primitive.NewObjectID(), Name: "Clothing", Path: domain.CategoryRootPath}
+	men := domain.Category{ID: primitive.NewObjectID(), Name: "Men", ParentID: &clothing.ID, Path: clothing.SubtreePath(), Depth: 1}
+	shirts := domain.Category{ID: primitive.NewObjectID(), Name: "Shirts", ParentID: &men.ID, Path: men.SubtreePath(), Depth: 2}
+	mockRepo.On("FindByPathPrefix", mock.Anything, domain.CategoryRootPath).Return([]domain.Category{clothing, men, shirts}, nil)
+
+	tree, err := uc.GetTree(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, tree, 1)
+	assert.Equal(t, "Clothing", tree[0].Name)
+	assert.Equal(t, "Men", tree[0].Children[0].Name)
+	assert.Equal(t, "Shirts", tree[0].Children[0].Children[0].Name)
+}
+
+func TestCategoryUseCase_EnsurePaths(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	legacy := domain.Category{ID: primitive.NewObjectID(), Name: "Legacy"}
+	placed := domain.Category{ID: primitive.NewObjectID(), Name: "Placed", Path: domain.CategoryRootPath}
+	mockRepo.On("Each", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(1).(func(*domain.Category) error)
+		fn(&legacy)
+		fn(&placed)
+	}).Return(nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	n, err := uc.EnsurePaths(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	mockRepo.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+		return c.ID == legacy.ID && c.Path == domain.CategoryRootPath && c.Depth == 0
+	}))
+}
+
+func TestCategoryUseCase_GetBreadcrumbs(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	clothing := domain.Category{ID: primitive.NewObjectID(), Name: "Clothing", Path: domain.CategoryRootPath}
+	men := domain.Category{ID: primitive.NewObjectID(), Name: "Men", ParentID: &clothing.ID, Path: clothing.SubtreePath(), Depth: 1}
+	shirts := &domain.Category{ID: primitive.NewObjectID(), Name: "Shirts", ParentID: &men.ID, Path: men.SubtreePath(), Depth: 2}
+	mockRepo.On("FindByID", mock.Anything, shirts.ID.Hex()).Return(shirts, nil)
+	mockRepo.On("FindByIDs", mock.Anything, []primitive.ObjectID{clothing.ID, men.ID}).Return([]domain.Category{men, clothing}, nil)
+
+	breadcrumbs, err := uc.GetBreadcrumbs(context.Background(), shirts.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Clothing", "Men", "Shirts"}, []string{breadcrumbs[0].Name, breadcrumbs[1].Name, breadcrumbs[2].Name})
+}
+
 func TestCategoryUseCase_Delete_ValidID(t *testing.T) {
 	mockRepo := new(MockCategoryRepository)
 	uc := NewCategoryUseCase(mockRepo)
 
 	categoryID := primitive.NewObjectID().Hex()
+	mockRepo.On("HasChildren", mock.Anything, categoryID).Return(false, nil)
 	mockRepo.On("Delete", mock.Anything, categoryID).Return(nil)
 
 	err := uc.Delete(context.Background(), categoryID)
@@ -129,6 +310,18 @@ func TestCategoryUseCase_Delete_ValidID(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCategoryUseCase_Delete_WithChildren(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	categoryID := primitive.NewObjectID().Hex()
+	mockRepo.On("HasChildren", mock.Anything, categoryID).Return(true, nil)
+
+	err := uc.Delete(context.Background(), categoryID)
+	assert.ErrorIs(t, err, domain.ErrCategoryHasChildren)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, categoryID)
+}
+
 func TestCategoryUseCase_Delete_InvalidID(t *testing.T) {
 	mockRepo := new(MockCategoryRepository)
 	uc := NewCategoryUseCase(mockRepo)
@@ -137,3 +330,30 @@ func TestCategoryUseCase_Delete_InvalidID(t *testing.T) {
 	err := uc.Delete(context.Background(), invalidID)
 	assert.ErrorIs(t, err, domain.ErrInvalidCategoryID)
 }
+
+func TestCategoryUseCase_Restore_RestoresArchivedAncestors(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	uc := NewCategoryUseCase(mockRepo)
+
+	rootID := primitive.NewObjectID()
+	parentID := primitive.NewObjectID()
+	deletedAt := time.Now()
+	category := &domain.Category{
+		ID:        primitive.NewObjectID(),
+		ParentID:  &parentID,
+		Path:      "," + rootID.Hex() + "," + parentID.Hex() + ",",
+		Depth:     2,
+		DeletedAt: &deletedAt,
+	}
+	categoryID := category.ID.Hex()
+
+	mockRepo.On("FindArchivedByID", mock.Anything, categoryID).Return(category, nil)
+	mockRepo.On("Restore", mock.Anything, rootID.Hex()).Return(domain.ErrCategoryNotFound)
+	mockRepo.On("Restore", mock.Anything, parentID.Hex()).Return(nil)
+	mockRepo.On("Restore", mock.Anything, categoryID).Return(nil)
+
+	restored, err := uc.Restore(context.Background(), categoryID)
+	assert.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/condition"
+)
+
+// discountConditionSchema is everything a rule condition can read. cart and
+// user fields describe the whole cart and its owner; item fields describe
+// one line.
+var discountConditionSchema = condition.Schema{
+	"cart.subtotal":   condition.Number,
+	"cart.quantity":   condition.Number,
+	"cart.lines":      condition.Number,
+	"user.orders":     condition.Number,
+	"user.points":     condition.Number,
+	"user.role":       condition.String,
+	"item.product_id": condition.String,
+	"item.name":       condition.String,
+	"item.category":   condition.String,
+	"item.sku":        condition.String,
+	"item.price":      condition.Number,
+	"item.quantity":   condition.Number,
+	"item.total":      condition.Number,
+	"item.on_sale":    condition.Bool,
+}
+
+func compileDiscountCondition(src string) (*condition.Program, error) {
+	program, err := condition.Compile(src, discountConditionSchema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidCondition, err)
+	}
+	return program, nil
+}
+
+// conditionItems narrows the cart to the lines the rule's condition allows.
+// A condition that reads no item fields keeps the whole cart or nothing, and
+// one that reads user fields never matches a cart without a known owner. The
+// customer's profile is only loaded when the condition reads it.
+func conditionItems(ctx context.Context, rule domain.DiscountRule, cartItems []domain.CartItem, customer *customerEligibility) ([]domain.CartItem, error) {
+	if rule.Condition == "" {
+		return cartItems, nil
+	}
+	program, err := compileDiscountCondition(rule.Condition)
+	if err != nil {
+		return nil, nil
+	}
+
+	var profile *domain.CustomerProfile
+	if program.Uses("user.") {
+		if profile, err = customer.customerProfile(ctx); err != nil || profile == nil {
+			return nil, err
+		}
+	}
+
+	vars := condition.Vars{
+		"cart.subtotal": calculateTotalPrice(cartItems).Float64(),
+		"cart.lines":    len(cartItems),
+	}
+	var quantity int
+	for _, item := range cartItems {
+		quantity += item.Quantity
+	}
+	vars["cart.quantity"] = quantity
+	if profile != nil {
+		vars["user.orders"] = profile.Orders
+		vars["user.points"] = profile.Points
+		vars["user.role"] = profile.Role
+	}
+
+	if !program.Uses("item.") {
+		if program.Eval(vars) {
+			return cartItems, nil
+		}
+		return nil, nil
+	}
+
+	var items []domain.CartItem
+	for _, item := range cartItems {
+		vars["item.product_id"] = item.ProductId.Hex()
+		vars["item.name"] = item.ProductName
+		vars["item.category"] = item.Category
+		vars["item.sku"] = item.VariantSKU
+		vars["item.price"] = item.UnitPrice.Float64()
+		vars["item.quantity"] = item.Quantity
+		vars["item.total"] = item.TotalPrice.Float64()
+		vars["item.on_sale"] = item.OnSale
+		if program.Eval(vars) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
@@ -7,24 +7,100 @@ import (
 	"play-to-win-api/internal/constants"
 	"play-to-win-api/internal/domain"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type discountRuleUseCase struct {
-	discountRuleRepo domain.DiscountRuleRepository
+	discountRuleRepo       domain.DiscountRuleRepository
+	campaignRepo           domain.CampaignRepository
+	appliedDiscountUseCase domain.AppliedDiscountUseCase
+	segmentUseCase         domain.SegmentUseCase
+	conflicts              conflictChecker
+	rounding               domain.Rounding
 }
 
-func NewDiscountRuleUseCase(dr domain.DiscountRuleRepository) domain.DiscountRuleUseCase {
+func NewDiscountRuleUseCase(dr domain.DiscountRuleRepository, cr domain.CampaignRepository, adu domain.AppliedDiscountUseCase, su domain.SegmentUseCase, rounding domain.Rounding) domain.DiscountRuleUseCase {
 	return &discountRuleUseCase{
-		discountRuleRepo: dr,
+		discountRuleRepo:       dr,
+		campaignRepo:           cr,
+		appliedDiscountUseCase: adu,
+		segmentUseCase:         su,
+		conflicts:              conflictChecker{campaignRepo: cr, discountRuleRepo: dr},
+		rounding:               rounding,
 	}
 }
 
 func (uc *discountRuleUseCase) Create(ctx context.Context, discountRule *domain.DiscountRule) error {
+	if err := validateDiscountRule(discountRule); err != nil {
+		return err
+	}
+	if err := uc.checkConflicts(ctx, discountRule); err != nil {
+		return err
+	}
 	return uc.discountRuleRepo.Create(ctx, discountRule)
 }
 
+// validateDiscountRule rejects rules that could only fail once evaluated
+// against a cart.
+func validateDiscountRule(discountRule *domain.DiscountRule) error {
+	if !domain.ValidDiscountType(discountRule.DiscuntType) {
+		return domain.ErrInvalidDiscountType
+	}
+	if discountRule.Currency != "" {
+		discountRule.Currency = domain.Currency(strings.ToUpper(string(discountRule.Currency)))
+		if !discountRule.Currency.Valid() {
+			return domain.ErrInvalidCurrency
+		}
+	}
+	if discountRule.Condition != "" {
+		if _, err := compileDiscountCondition(discountRule.Condition); err != nil {
+			return err
+		}
+	}
+	switch discountRule.DiscuntType {
+	case domain.DiscountTypeTiered:
+		return discountRule.TieredPricing().Validate()
+	case domain.DiscountTypeBOGO, domain.DiscountTypeBuyXGetY:
+		if !discountRule.BuyXGetY().Valid() {
+			return domain.ErrInvalidOffer
+		}
+	case domain.DiscountTypeBundle:
+		if !discountRule.Bundle().Valid() {
+			return domain.ErrInvalidBundle
+		}
+	}
+	return nil
+}
+
+// checkConflicts checks the rule's campaign as it would look once the rule is
+// saved, so adding a rule can surface stacking the campaign alone did not.
+func (uc *discountRuleUseCase) checkConflicts(ctx context.Context, discountRule *domain.DiscountRule) error {
+	campaign, err := uc.campaignRepo.FindByID(ctx, discountRule.CampaignID.Hex())
+	if err != nil {
+		return domain.ErrCampaignNotFound
+	}
+
+	existing, err := uc.discountRuleRepo.FindByCampaignIDs(ctx, []primitive.ObjectID{campaign.ID})
+	if err != nil {
+		return err
+	}
+	rules := []domain.DiscountRule{*discountRule}
+	for _, rule := range existing {
+		if rule.ID != discountRule.ID {
+			rules = append(rules, rule)
+		}
+	}
+
+	conflicts, err := uc.conflicts.check(ctx, campaign, rules)
+	if err != nil {
+		return err
+	}
+	discountRule.Conflicts, err = resolveConflicts(conflicts)
+	return err
+}
+
 func (uc *discountRuleUseCase) GetByID(ctx context.Context, id string) (*domain.DiscountRule, error) {
 	if !primitive.IsValidObjectID(id) {
 		return nil, domain.ErrInvalidDiscountRuleID
@@ -40,11 +116,17 @@ func (uc *discountRuleUseCase) GetByID(ctx context.Context, id string) (*domain.
 	return discountRule, nil
 }
 
-func (uc *discountRuleUseCase) GetAll(ctx context.Context) ([]domain.DiscountRule, error) {
-	return uc.discountRuleRepo.FindAll(ctx)
+func (uc *discountRuleUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.DiscountRule, *domain.Pagination, error) {
+	return uc.discountRuleRepo.FindAll(ctx, query)
 }
 
 func (uc *discountRuleUseCase) Update(ctx context.Context, discountRule *domain.DiscountRule) error {
+	if err := validateDiscountRule(discountRule); err != nil {
+		return err
+	}
+	if err := uc.checkConflicts(ctx, discountRule); err != nil {
+		return err
+	}
 	return uc.discountRuleRepo.Update(ctx, discountRule)
 }
 
@@ -62,3 +144,166 @@ func (uc *discountRuleUseCase) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// Evaluate applies every rule of the campaigns live at the given time to the
+// cart, highest priority campaign first. Each rule is measured against the
+// undiscounted cart and the discounts are summed, never exceeding the
+// subtotal. An exclusive campaign only applies if nothing else has, and stops
+// any later campaign from applying. Campaigns targeted at segments the
+// customer is not in are skipped, as are rules with fixed amounts in another
+// currency. Rules that do not match the cart, or are misconfigured,
+// contribute nothing rather than failing the cart.
+func (uc *discountRuleUseCase) Evaluate(ctx context.Context, customerID primitive.ObjectID, currency domain.Currency, cartItems []domain.CartItem, at time.Time) (*domain.DiscountEvaluation, error) {
+	if err := validateCartItems(cartItems); err != nil {
+		return nil, err
+	}
+
+	rules, err := uc.discountRuleRepo.FindLive(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation := &domain.DiscountEvaluation{
+		Currency: currency.OrBase(),
+		Subtotal: calculateTotalPrice(cartItems),
+		Lines:    []domain.DiscountLine{},
+	}
+	customer := &customerEligibility{
+		segmentUseCase: uc.segmentUseCase,
+		customerID:     customerID,
+		campaigns:      map[primitive.ObjectID]bool{},
+	}
+	applied := map[primitive.ObjectID]bool{}
+	exclusiveApplied := false
+	for _, rule := range rules {
+		if !rule.AppliesTo(currency) {
+			continue
+		}
+		if !applied[rule.CampaignID] && (exclusiveApplied || (rule.CampaignExclusive && len(applied) > 0)) {
+			continue
+		}
+		eligible, err := customer.eligible(ctx, rule.CampaignID, rule.CampaignSegmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		if !eligible {
+			continue
+		}
+
+		items, err := conditionItems(ctx, rule, cartItems, customer)
+		if err != nil {
+			return nil, err
+		}
+		amount, allocations := ruleDiscount(ctx, uc.appliedDiscountUseCase, uc.rounding.Mode, rule, items)
+		if amount <= 0 {
+			continue
+		}
+		applied[rule.CampaignID] = true
+		exclusiveApplied = exclusiveApplied || rule.CampaignExclusive
+		evaluation.Discount += amount
+		evaluation.Lines = append(evaluation.Lines, domain.DiscountLine{
+			RuleID:       rule.ID,
+			CampaignID:   rule.CampaignID,
+			CampaignName: rule.CampaignName,
+			DiscountType: rule.DiscuntType,
+			Amount:       amount,
+			Items:        allocations,
+		})
+	}
+
+	evaluation.Discount = domain.MinMoney(evaluation.Discount, evaluation.Subtotal)
+	evaluation.Total = evaluation.Subtotal - evaluation.Discount
+	return evaluation, nil
+}
+
+// ruleDiscount measures one rule against the items with the shared discount
+// calculators. Types that discount particular units also return how the
+// amount is spread over the cart lines.
+func ruleDiscount(ctx context.Context, calculator domain.AppliedDiscountUseCase, mode domain.RoundingMode, rule domain.DiscountRule, cartItems []domain.CartItem) (domain.Money, []domain.DiscountAllocation) {
+	items := cartItems
+	if rule.ExcludeSaleItems {
+		items, _ = domain.SplitSaleItems(cartItems)
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	var allocations []domain.DiscountAllocation
+	var err error
+	switch rule.DiscuntType {
+	case domain.DiscountTypeFixedAmount:
+		allocations, err = calculator.CalculateFixedAmountDiscount(ctx, items, rule.Amount)
+	case domain.DiscountTypePercentage:
+		allocations, err = calculator.CalculatePercentageDiscount(ctx, items, rule.Percentage)
+	case domain.DiscountTypeCategory:
+		allocations, err = calculator.CalculateCategoryDiscount(ctx, items, rule.ItemCategory, rule.Percentage)
+	case domain.DiscountTypeVariant:
+		allocations, err = calculator.CalculateVariantDiscount(ctx, items, rule.ItemSKU, rule.Percentage)
+	case domain.DiscountTypeSpecial:
+		allocations, err = calculator.CalculateSpecialDiscount(ctx, items, rule.ThresholdAmount, rule.Amount)
+	case domain.DiscountTypeTiered:
+		allocations, err = calculator.CalculateTieredDiscount(ctx, items, rule.TieredPricing())
+	case domain.DiscountTypeBOGO, domain.DiscountTypeBuyXGetY:
+		allocations, err = calculator.CalculateBuyXGetYDiscount(ctx, items, rule.BuyXGetY())
+	case domain.DiscountTypeBundle:
+		allocations, err = calculator.CalculateBundleDiscount(ctx, items, rule.Bundle())
+	default:
+		return 0, nil
+	}
+	if err != nil {
+		return 0, nil
+	}
+
+	discount := domain.SumAllocations(allocations)
+	if rule.MaxDiscountPercentage > 0 {
+		if capped := calculateTotalPrice(items).Percent(rule.MaxDiscountPercentage, mode); discount > capped {
+			allocations = domain.ScaleAllocations(allocations, capped)
+			discount = capped
+		}
+	}
+	return discount, allocations
+}
+
+// customerEligibility answers, once per campaign, whether the customer is in
+// the campaign's segments. The profile is only loaded once a live rule needs
+// it.
+type customerEligibility struct {
+	segmentUseCase domain.SegmentUseCase
+	customerID     primitive.ObjectID
+	profile        *domain.CustomerProfile
+	loaded         bool
+	campaigns      map[primitive.ObjectID]bool
+}
+
+func (e *customerEligibility) eligible(ctx context.Context, campaignID primitive.ObjectID, segmentIDs []primitive.ObjectID) (bool, error) {
+	if len(segmentIDs) == 0 {
+		return true, nil
+	}
+	if eligible, ok := e.campaigns[campaignID]; ok {
+		return eligible, nil
+	}
+
+	profile, err := e.customerProfile(ctx)
+	if err != nil {
+		return false, err
+	}
+	eligible, err := e.segmentUseCase.Eligible(ctx, profile, segmentIDs)
+	if err != nil {
+		return false, err
+	}
+	e.campaigns[campaignID] = eligible
+	return eligible, nil
+}
+
+// customerProfile is nil for a cart without a known owner.
+func (e *customerEligibility) customerProfile(ctx context.Context) (*domain.CustomerProfile, error) {
+	if !e.loaded && !e.customerID.IsZero() {
+		profile, err := e.segmentUseCase.Profile(ctx, e.customerID)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+		e.profile = profile
+	}
+	e.loaded = true
+	return e.profile, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateDiscountRule(t *testing.T) {
+	mug := primitive.NewObjectID()
+
+	tests := []struct {
+		name string
+		rule domain.DiscountRule
+		err  error
+	}{
+		{"unknown type", domain.DiscountRule{DiscuntType: "free_lunch"}, domain.ErrInvalidDiscountType},
+		{"percentage", domain.DiscountRule{DiscuntType: domain.DiscountTypePercentage, Percentage: 10}, nil},
+		{"bogo", domain.DiscountRule{DiscuntType: domain.DiscountTypeBOGO, BuyProductIDs: []primitive.ObjectID{mug}}, nil},
+		{"bogo without target", domain.DiscountRule{DiscuntType: domain.DiscountTypeBOGO}, domain.ErrInvalidOffer},
+		{"buy x get y", domain.DiscountRule{DiscuntType: domain.DiscountTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1, BuyCategory: "Mugs"}, nil},
+		{"buy x get y without quantities", domain.DiscountRule{DiscuntType: domain.DiscountTypeBuyXGetY, BuyCategory: "Mugs"}, domain.ErrInvalidOffer},
+		{"buy x get y with negative quantity", domain.DiscountRule{DiscuntType: domain.DiscountTypeBuyXGetY, BuyQuantity: -1, GetQuantity: 1, BuyCategory: "Mugs"}, domain.ErrInvalidOffer},
+		{"bundle", domain.DiscountRule{DiscuntType: domain.DiscountTypeBundle, BundleProductIDs: []primitive.ObjectID{mug}, BundlePrice: 99_00}, nil},
+		{"bundle without products", domain.DiscountRule{DiscuntType: domain.DiscountTypeBundle, BundlePrice: 99_00}, domain.ErrInvalidBundle},
+		{"bundle without price", domain.DiscountRule{DiscuntType: domain.DiscountTypeBundle, BundleProductIDs: []primitive.ObjectID{mug}}, domain.ErrInvalidBundle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			assert.Equal(t, tt.err, validateDiscountRule(&rule))
+		})
+	}
+}
+
+// flatDiscount takes its whole amount off the first line.
+type flatDiscount struct {
+	domain.AppliedDiscountUseCase
+	amount domain.Money
+}
+
+func (c flatDiscount) CalculatePercentageDiscount(ctx context.Context, cartItems []domain.CartItem, percentage float64) ([]domain.DiscountAllocation, error) {
+	return []domain.DiscountAllocation{{ProductID: cartItems[0].ProductId, Quantity: 1, Amount: c.amount}}, nil
+}
+
+func TestRuleDiscount_CapUsesRoundingMode(t *testing.T) {
+	items := []domain.CartItem{{ProductId: primitive.NewObjectID(), Quantity: 1, UnitPrice: 25, TotalPrice: 25}}
+	rule := domain.DiscountRule{DiscuntType: domain.DiscountTypePercentage, Percentage: 50, MaxDiscountPercentage: 10}
+
+	// 10% of 0.25 is 2.5 satang.
+	even, _ := ruleDiscount(context.Background(), flatDiscount{amount: 12}, domain.RoundHalfEven, rule, items)
+	up, _ := ruleDiscount(context.Background(), flatDiscount{amount: 12}, domain.RoundHalfUp, rule, items)
+
+	assert.Equal(t, domain.Money(2), even)
+	assert.Equal(t, domain.Money(3), up)
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"play-to-win-api/internal/domain"
+	"strings"
+)
+
+type exchangeRateUseCase struct {
+	exchangeRateRepo domain.ExchangeRateRepository
+	roundingMode     domain.RoundingMode
+}
+
+func NewExchangeRateUseCase(er domain.ExchangeRateRepository, mode domain.RoundingMode) domain.ExchangeRateUseCase {
+	return &exchangeRateUseCase{
+		exchangeRateRepo: er,
+		roundingMode:     mode,
+	}
+}
+
+func (uc *exchangeRateUseCase) GetAll(ctx context.Context) ([]domain.ExchangeRate, error) {
+	return uc.exchangeRateRepo.FindAll(ctx)
+}
+
+func (uc *exchangeRateUseCase) Set(ctx context.Context, rate *domain.ExchangeRate) error {
+	rate.Currency = domain.Currency(strings.ToUpper(string(rate.Currency)))
+	if !rate.Currency.Valid() {
+		return domain.ErrInvalidCurrency
+	}
+	if rate.Currency == domain.BaseCurrency {
+		return domain.ErrBaseCurrencyRate
+	}
+	return uc.exchangeRateRepo.Upsert(ctx, rate)
+}
+
+// Delete takes any stored code, so rates saved before a currency stopped
+// being accepted can still be removed.
+func (uc *exchangeRateUseCase) Delete(ctx context.Context, currency string) error {
+	return uc.exchangeRateRepo.Delete(ctx, domain.Currency(strings.ToUpper(currency)))
+}
+
+func (uc *exchangeRateUseCase) Supported(ctx context.Context, currency domain.Currency) (bool, error) {
+	_, err := uc.rate(ctx, currency)
+	if errors.Is(err, domain.ErrUnsupportedCurrency) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (uc *exchangeRateUseCase) Convert(ctx context.Context, amount domain.Money, from, to domain.Currency) (domain.Money, error) {
+	if from.OrBase() == to.OrBase() {
+		return amount, nil
+	}
+	fromRate, err := uc.rate(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := uc.rate(ctx, to)
+	if err != nil {
+		return 0, err
+	}
+	return amount.Exchange(fromRate, toRate, uc.roundingMode), nil
+}
+
+// rate returns the units of currency per unit of the base currency.
+func (uc *exchangeRateUseCase) rate(ctx context.Context, currency domain.Currency) (float64, error) {
+	if currency.OrBase() == domain.BaseCurrency {
+		return 1, nil
+	}
+	rate, err := uc.exchangeRateRepo.FindByCurrency(ctx, currency)
+	if errors.Is(err, domain.ErrExchangeRateNotFound) {
+		return 0, domain.ErrUnsupportedCurrency
+	}
+	if err != nil {
+		return 0, err
+	}
+	return rate.Rate, nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"play-to-win-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxCorrectionAttempts bounds how often a stock count is retried when sales
+// keep moving the level between the read and the write.
+const maxCorrectionAttempts = 5
+
+type inventoryUseCase struct {
+	productRepo       domain.ProductRepository
+	movementRepo      domain.StockMovementRepository
+	publisher         domain.EventPublisher
+	lowStockThreshold int
+}
+
+func NewInventoryUseCase(pr domain.ProductRepository, mr domain.StockMovementRepository, publisher domain.EventPublisher, lowStockThreshold int) domain.InventoryUseCase {
+	return &inventoryUseCase{
+		productRepo:       pr,
+		movementRepo:      mr,
+		publisher:         publisher,
+		lowStockThreshold: lowStockThreshold,
+	}
+}
+
+func (uc *inventoryUseCase) Adjust(ctx context.Context, productID string, actor domain.Actor, adjustment domain.StockAdjustment) (*domain.StockMovement, error) {
+	if !adjustment.Type.Valid() {
+		return nil, domain.ErrInvalidMovementType
+	}
+	if adjustment.Type != domain.StockMovementCorrection && adjustment.Quantity <= 0 {
+		return nil, domain.ErrInvalidStockQuantity
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrInvalidProductID
+	}
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	var variant *domain.ProductVariant
+	if adjustment.VariantID != "" {
+		variantID, err := primitive.ObjectIDFromHex(adjustment.VariantID)
+		if err != nil {
+			return nil, domain.ErrInvalidVariantID
+		}
+		if variant = product.Variant(variantID); variant == nil {
+			return nil, domain.ErrVariantNotFound
+		}
+	} else if len(product.Variants) > 0 {
+		return nil, domain.ErrVariantRequired
+	}
+
+	movement := &domain.StockMovement{
+		ProductID:  objectID,
+		Type:       adjustment.Type,
+		Reason:     adjustment.Reason,
+		Reference:  adjustment.Reference,
+		ActorID:    actor.ID,
+		ActorEmail: actor.Email,
+	}
+	if variant != nil {
+		movement.VariantID = &variant.ID
+	}
+
+	delta := adjustment.Quantity
+	if adjustment.Type == domain.StockMovementSale {
+		delta = -adjustment.Quantity
+	}
+
+	var updated *domain.Product
+	if adjustment.Type == domain.StockMovementCorrection {
+		updated, delta, err = uc.correct(ctx, product, movement.VariantID, adjustment.Quantity)
+	} else {
+		updated, err = uc.productRepo.AdjustStock(ctx, product.ID, movement.VariantID, delta)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.record(ctx, updated, movement, delta); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// correct sets the counted level. The write only lands while stock still
+// stands where it was read, so a sale in between is re-read and kept rather
+// than overwritten, and the delta returned is the one actually applied.
+func (uc *inventoryUseCase) correct(ctx context.Context, product *domain.Product, variantID *primitive.ObjectID, level int) (*domain.Product, int, error) {
+	for attempt := 1; ; attempt++ {
+		var variant *domain.ProductVariant
+		if variantID != nil {
+			if variant = product.Variant(*variantID); variant == nil {
+				return nil, 0, domain.ErrVariantNotFound
+			}
+		}
+		observed := product.StockFor(variant)
+
+		updated, err := uc.productRepo.SetStock(ctx, product.ID, variantID, observed, level)
+		if err == nil {
+			return updated, level - observed, nil
+		}
+		if !errors.Is(err, domain.ErrStockChanged) || attempt == maxCorrectionAttempts {
+			return nil, 0, err
+		}
+		if product, err = uc.productRepo.FindByID(ctx, product.ID.Hex()); err != nil {
+			return nil, 0, domain.ErrProductNotFound
+		}
+	}
+}
+
+// record writes the ledger entry for a change already applied and raises
+// back-in-stock and low-stock events when it takes the level across them.
+// The stock has moved by then, so a failed publish is logged, not returned.
+func (uc *inventoryUseCase) record(ctx context.Context, updated *domain.Product, movement *domain.StockMovement, delta int) error {
+	var variant *domain.ProductVariant
+	if movement.VariantID != nil {
+		variant = updated.Variant(*movement.VariantID)
+	}
+	movement.Quantity = delta
+	movement.StockAfter = updated.StockFor(variant)
+	movement.StockBefore = movement.StockAfter - delta
+
+	if err := uc.movementRepo.Create(ctx, movement); err != nil {
+		return err
+	}
+
+	if movement.StockBefore <= 0 && movement.StockAfter > 0 {
+		restock := domain.BackInStock{
+			ProductID:   updated.ID,
+			VariantID:   movement.VariantID,
+			ProductName: updated.Name,
+			Stock:       movement.StockAfter,
+		}
+		if err := uc.publisher.Publish(ctx, domain.NewEvent(domain.EventProductBackInStock, restock)); err != nil {
+			log.Printf("Product %s: failed to publish %s: %v", updated.ID.Hex(), domain.EventProductBackInStock, err)
+		}
+	}
+
+	threshold := updated.LowStockLevel(uc.lowStockThreshold)
+	if movement.StockAfter <= threshold && movement.StockBefore > threshold {
+		alert := domain.LowStockAlert{
+			ProductID:   updated.ID,
+			VariantID:   movement.VariantID,
+			ProductName: updated.Name,
+			SKU:         updated.SKU,
+			Stock:       movement.StockAfter,
+			Threshold:   threshold,
+		}
+		if variant != nil {
+			alert.SKU = variant.SKU
+		}
+		if err := uc.publisher.Publish(ctx, domain.NewEvent(domain.EventProductLowStock, alert)); err != nil {
+			log.Printf("Product %s: failed to publish %s: %v", updated.ID.Hex(), domain.EventProductLowStock, err)
+		}
+	}
+	return nil
+}
+
+func (uc *inventoryUseCase) GetMovements(ctx context.Context, productID string, query domain.ListQuery) ([]domain.StockMovement, *domain.Pagination, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidProductID
+	}
+	return uc.movementRepo.FindByProductID(ctx, objectID, query)
+}
+
+func (uc *inventoryUseCase) GetLowStock(ctx context.Context, query domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	return uc.productRepo.FindLowStock(ctx, uc.lowStockThreshold, query)
+}
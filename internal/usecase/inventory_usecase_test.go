@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// stockroom keeps one product's stock. sellBeforeWrite units are sold
+// between the correction's read and its write, once.
+type stockroom struct {
+	domain.ProductRepository
+	product         domain.Product
+	sellBeforeWrite int
+}
+
+func (r *stockroom) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	product := r.product
+	return &product, nil
+}
+
+func (r *stockroom) SetStock(ctx context.Context, id primitive.ObjectID, variantID *primitive.ObjectID, observed, level int) (*domain.Product, error) {
+	if r.sellBeforeWrite > 0 {
+		r.product.Stock -= r.sellBeforeWrite
+		r.sellBeforeWrite = 0
+	}
+	if r.product.Stock != observed {
+		return nil, domain.ErrStockChanged
+	}
+	r.product.Stock = level
+	product := r.product
+	return &product, nil
+}
+
+func (r *stockroom) AdjustStock(ctx context.Context, id primitive.ObjectID, variantID *primitive.ObjectID, delta int) (*domain.Product, error) {
+	r.product.Stock += delta
+	product := r.product
+	return &product, nil
+}
+
+type movementLog struct {
+	domain.StockMovementRepository
+	movements []domain.StockMovement
+}
+
+func (r *movementLog) Create(ctx context.Context, movement *domain.StockMovement) error {
+	r.movements = append(r.movements, *movement)
+	return nil
+}
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, event domain.Event) error {
+	return errors.New("broker unavailable")
+}
+
+func TestInventoryUseCase_Adjust_CorrectionRereadsAfterConcurrentSale(t *testing.T) {
+	products := &stockroom{product: domain.Product{ID: primitive.NewObjectID(), Stock: 10}, sellBeforeWrite: 3}
+	movements := &movementLog{}
+	uc := NewInventoryUseCase(products, movements, failingPublisher{}, 0)
+
+	movement, err := uc.Adjust(context.Background(), products.product.ID.Hex(), domain.Actor{}, domain.StockAdjustment{
+		Type: domain.StockMovementCorrection, Quantity: 4, Reason: "stocktake",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, products.product.Stock)
+	require.Len(t, movements.movements, 1)
+	assert.Equal(t, 7, movement.StockBefore)
+	assert.Equal(t, 4, movement.StockAfter)
+	assert.Equal(t, -3, movement.Quantity)
+}
+
+func TestInventoryUseCase_Adjust_LogsFailedPublish(t *testing.T) {
+	products := &stockroom{product: domain.Product{ID: primitive.NewObjectID(), Stock: 0}}
+	movements := &movementLog{}
+	uc := NewInventoryUseCase(products, movements, failingPublisher{}, 0)
+
+	// Restocking from zero raises a back-in-stock event, which fails to
+	// publish after the stock and ledger are already written.
+	movement, err := uc.Adjust(context.Background(), products.product.ID.Hex(), domain.Actor{}, domain.StockAdjustment{
+		Type: domain.StockMovementRestock, Quantity: 5, Reason: "delivery",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, movement.StockAfter)
+	assert.Len(t, movements.movements, 1)
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type notificationUseCase struct {
+	notificationRepo domain.NotificationRepository
+}
+
+func NewNotificationUseCase(nr domain.NotificationRepository) domain.NotificationUseCase {
+	return &notificationUseCase{notificationRepo: nr}
+}
+
+func (uc *notificationUseCase) GetByUserID(ctx context.Context, userID string, query domain.ListQuery) ([]domain.Notification, *domain.Pagination, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidUserID
+	}
+	return uc.notificationRepo.FindByUserID(ctx, userObjectID, query)
+}
+
+func (uc *notificationUseCase) MarkRead(ctx context.Context, userID, id string) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	notificationID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidNotificationID
+	}
+	return uc.notificationRepo.MarkRead(ctx, userObjectID, notificationID)
+}
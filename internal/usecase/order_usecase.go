@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orderUseCase struct {
+	orderRepo           domain.OrderRepository
+	cartRepo            domain.CartRepository
+	cartItemRepo        domain.CartItemRepository
+	campaignRepo        domain.CampaignRepository
+	redemptionRepo      domain.CampaignRedemptionRepository
+	discountRuleUseCase domain.DiscountRuleUseCase
+	inventoryUseCase    domain.InventoryUseCase
+	exchangeRateUseCase domain.ExchangeRateUseCase
+	taxUseCase          domain.TaxUseCase
+	publisher           domain.EventPublisher
+}
+
+func NewOrderUseCase(or domain.OrderRepository, cartRepo domain.CartRepository, cir domain.CartItemRepository, cr domain.CampaignRepository, rr domain.CampaignRedemptionRepository, dru domain.DiscountRuleUseCase, iu domain.InventoryUseCase, eru domain.ExchangeRateUseCase, tu domain.TaxUseCase, ep domain.EventPublisher) domain.OrderUseCase {
+	return &orderUseCase{
+		orderRepo:           or,
+		cartRepo:            cartRepo,
+		cartItemRepo:        cir,
+		campaignRepo:        cr,
+		redemptionRepo:      rr,
+		discountRuleUseCase: dru,
+		inventoryUseCase:    iu,
+		exchangeRateUseCase: eru,
+		taxUseCase:          tu,
+		publisher:           ep,
+	}
+}
+
+// Checkout turns the cart into an order. Stock is taken through the
+// inventory ledger and every campaign discount is charged against its caps;
+// a campaign that has run out simply drops out of the order instead of
+// failing it. Tax is charged on what the campaigns leave. If any step fails,
+// the steps before it are undone.
+func (uc *orderUseCase) Checkout(ctx context.Context, actor domain.Actor, cartID string) (*domain.Order, error) {
+	userID, err := primitive.ObjectIDFromHex(actor.ID)
+	if err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	if !primitive.IsValidObjectID(cartID) {
+		return nil, domain.ErrInvalidCartID
+	}
+	cart, err := uc.cartRepo.FindByID(ctx, cartID)
+	if err != nil || cart.User.ID != userID {
+		return nil, domain.ErrCartNotFound
+	}
+
+	items, err := uc.cartItemRepo.FindByCartID(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, domain.ErrEmptyCart
+	}
+
+	now := time.Now()
+	evaluation, err := uc.discountRuleUseCase.Evaluate(ctx, cart.User.ID, cart.Currency, items, now)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &domain.Order{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		CartID:    cart.ID,
+		Items:     orderItems(items),
+		Currency:  cart.Currency.OrBase(),
+		Subtotal:  evaluation.Subtotal,
+		Discounts: []domain.DiscountLine{},
+		Status:    domain.OrderPlaced,
+	}
+
+	var undo rollback
+	if err := uc.takeStock(ctx, actor, order, &undo); err != nil {
+		undo.run()
+		return nil, err
+	}
+	if err := uc.redeem(ctx, order, evaluation.Lines, now, &undo); err != nil {
+		undo.run()
+		return nil, err
+	}
+
+	for _, line := range order.Discounts {
+		order.Discount += line.Amount
+	}
+	order.Discount = domain.MinMoney(order.Discount, order.Subtotal)
+	tax, err := uc.taxUseCase.Calculate(ctx, cart.TaxRegion, items, order.Discounts)
+	if err != nil {
+		undo.run()
+		return nil, err
+	}
+	order.Tax = tax
+	order.Total = order.Subtotal - order.Discount + tax.Added()
+
+	if err := uc.orderRepo.Create(ctx, order); err != nil {
+		undo.run()
+		return nil, err
+	}
+
+	// The order stands even if emptying the cart fails; stale items are
+	// harmless and the customer can remove them.
+	for _, item := range items {
+		if err := uc.cartItemRepo.Delete(ctx, item.ID.Hex()); err != nil {
+			log.Printf("Order %s: failed to remove cart item %s: %v", order.ID.Hex(), item.ID.Hex(), err)
+		}
+	}
+
+	if err := uc.publisher.Publish(ctx, domain.NewEvent(domain.EventOrderPlaced, *order)); err != nil {
+		log.Printf("Order %s: failed to publish %s: %v", order.ID.Hex(), domain.EventOrderPlaced, err)
+	}
+	return order, nil
+}
+
+func (uc *orderUseCase) takeStock(ctx context.Context, actor domain.Actor, order *domain.Order, undo *rollback) error {
+	for _, item := range order.Items {
+		adjustment := domain.StockAdjustment{
+			Type:      domain.StockMovementSale,
+			Quantity:  item.Quantity,
+			Reason:    "Order checkout",
+			Reference: order.ID.Hex(),
+		}
+		if item.VariantID != nil {
+			adjustment.VariantID = item.VariantID.Hex()
+		}
+		productID := item.ProductID.Hex()
+		if _, err := uc.inventoryUseCase.Adjust(ctx, productID, actor, adjustment); err != nil {
+			return err
+		}
+
+		undo.add(func(ctx context.Context) error {
+			adjustment.Type = domain.StockMovementReturn
+			adjustment.Reason = "Checkout rolled back"
+			_, err := uc.inventoryUseCase.Adjust(ctx, productID, actor, adjustment)
+			return err
+		})
+	}
+	return nil
+}
+
+// redeem charges each campaign once for the sum of its lines. Budgets are
+// kept in the base currency, so the charge is converted from the order's
+// currency. When a budget only partly covers the discount, the campaign's
+// lines are scaled down to what was granted.
+func (uc *orderUseCase) redeem(ctx context.Context, order *domain.Order, lines []domain.DiscountLine, at time.Time, undo *rollback) error {
+	var campaignIDs []primitive.ObjectID
+	totals := map[primitive.ObjectID]domain.Money{}
+	for _, line := range lines {
+		if _, ok := totals[line.CampaignID]; !ok {
+			campaignIDs = append(campaignIDs, line.CampaignID)
+		}
+		totals[line.CampaignID] += line.Amount
+	}
+
+	for _, campaignID := range campaignIDs {
+		campaignID := campaignID
+		campaign, err := uc.campaignRepo.FindByID(ctx, campaignID.Hex())
+		if err != nil {
+			continue
+		}
+		total := totals[campaignID]
+		charge, err := uc.exchangeRateUseCase.Convert(ctx, total, order.Currency, domain.BaseCurrency)
+		if err != nil {
+			return err
+		}
+
+		claimed, err := uc.redemptionRepo.Claim(ctx, campaignID, order.UserID, campaign.MaxRedemptionsPerCustomer)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		redemption, err := uc.campaignRepo.Redeem(ctx, campaignID, charge, at)
+		if err != nil || redemption.Granted <= 0 {
+			if releaseErr := uc.redemptionRepo.Release(ctx, campaignID, order.UserID); releaseErr != nil && err == nil {
+				err = releaseErr
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		charged := redemption.Granted
+		undo.add(func(ctx context.Context) error {
+			if err := uc.campaignRepo.Unredeem(ctx, campaignID, charged); err != nil {
+				return err
+			}
+			return uc.redemptionRepo.Release(ctx, campaignID, order.UserID)
+		})
+
+		granted := total
+		if charged < charge {
+			if granted, err = uc.exchangeRateUseCase.Convert(ctx, charged, domain.BaseCurrency, order.Currency); err != nil {
+				return err
+			}
+			granted = domain.MinMoney(granted, total)
+		}
+
+		var campaignLines []domain.DiscountLine
+		var amounts []domain.Money
+		for _, line := range lines {
+			if line.CampaignID == campaignID {
+				campaignLines = append(campaignLines, line)
+				amounts = append(amounts, line.Amount)
+			}
+		}
+		for i, amount := range domain.Allocate(granted, amounts) {
+			order.Discounts = append(order.Discounts, campaignLines[i].ScaledTo(amount))
+		}
+
+		// The redemption is already recorded, so a lost notification must not
+		// roll it back.
+		if redemption.Exhausted {
+			if err := uc.publisher.Publish(ctx, domain.NewEvent(domain.EventCampaignExhausted, domain.CampaignTransition{
+				CampaignID: campaign.ID,
+				Name:       campaign.Name,
+				StartDate:  campaign.StartDate,
+				EndDate:    campaign.EndDate,
+			})); err != nil {
+				log.Printf("Campaign %s: failed to publish %s: %v", campaign.ID.Hex(), domain.EventCampaignExhausted, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (uc *orderUseCase) GetByID(ctx context.Context, userID, id string) (*domain.Order, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.ErrInvalidUserID
+	}
+	orderID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidOrderID
+	}
+	return uc.orderRepo.FindByID(ctx, userObjectID, orderID)
+}
+
+func (uc *orderUseCase) GetByUserID(ctx context.Context, userID string, query domain.ListQuery) ([]domain.Order, *domain.Pagination, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidUserID
+	}
+	return uc.orderRepo.FindByUserID(ctx, userObjectID, query)
+}
+
+func orderItems(items []domain.CartItem) []domain.OrderItem {
+	orderItems := make([]domain.OrderItem, 0, len(items))
+	for _, item := range items {
+		orderItems = append(orderItems, domain.OrderItem{
+			ProductID:   item.ProductId,
+			VariantID:   item.VariantId,
+			ProductName: item.ProductName,
+			VariantSKU:  item.VariantSKU,
+			CategoryID:  item.CategoryID,
+			Category:    item.Category,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			TotalPrice:  item.TotalPrice,
+			OnSale:      item.OnSale,
+			TaxClass:    item.TaxClass,
+		})
+	}
+	return orderItems
+}
+
+// rollback collects compensating steps and runs them newest first. They run
+// on a fresh context so a cancelled request still cleans up after itself.
+type rollback []func(ctx context.Context) error
+
+func (r *rollback) add(step func(ctx context.Context) error) {
+	*r = append(*r, step)
+}
+
+func (r rollback) run() {
+	for i := len(r) - 1; i >= 0; i-- {
+		if err := r[i](context.Background()); err != nil {
+			log.Printf("Checkout rollback step failed: %v", err)
+		}
+	}
+}
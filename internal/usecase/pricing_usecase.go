@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const priceChangeBatchSize = 100
+
+// priceApplier is shared by direct product edits and scheduled changes so
+// both leave the same history and derive the "was" price the same way.
+type priceApplier struct {
+	productRepo domain.ProductRepository
+	priceRepo   domain.PriceChangeRepository
+	searchIndex domain.SearchIndex
+	publisher   domain.EventPublisher
+}
+
+func (a *priceApplier) apply(ctx context.Context, product *domain.Product, change *domain.PriceChange, at time.Time) error {
+	history, err := a.priceRepo.FindAppliedSince(ctx, product.ID, at.Add(-domain.ReferencePriceWindow))
+	if err != nil {
+		return err
+	}
+
+	var wasPrice *domain.Money
+	if reference := domain.ReferencePrice(history, product.Price, at); reference > change.Price {
+		wasPrice = &reference
+	}
+
+	change.PreviousPrice = product.Price
+	if err := a.productRepo.UpdatePrice(ctx, product.ID, change.Price, wasPrice); err != nil {
+		return err
+	}
+	product.Price = change.Price
+	product.WasPrice = wasPrice
+	if err := a.searchIndex.Index(ctx, product); err != nil {
+		return err
+	}
+
+	if change.Price == change.PreviousPrice {
+		return nil
+	}
+	return a.publisher.Publish(ctx, domain.NewEvent(domain.EventProductPriceChanged, domain.PriceChanged{
+		ProductID:     product.ID,
+		ProductName:   product.Name,
+		Price:         change.Price,
+		PreviousPrice: change.PreviousPrice,
+	}))
+}
+
+func (a *priceApplier) record(ctx context.Context, product *domain.Product, price domain.Money, reason string, actor domain.Actor) (*domain.PriceChange, error) {
+	now := time.Now()
+	change := &domain.PriceChange{
+		ProductID:     product.ID,
+		Price:         price,
+		EffectiveFrom: now,
+		Status:        domain.PriceChangeApplied,
+		Reason:        reason,
+		ActorID:       actor.ID,
+		ActorEmail:    actor.Email,
+		AppliedAt:     &now,
+	}
+	if err := a.apply(ctx, product, change, now); err != nil {
+		return nil, err
+	}
+	if err := a.priceRepo.Create(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+type pricingUseCase struct {
+	productRepo domain.ProductRepository
+	priceRepo   domain.PriceChangeRepository
+	applier     *priceApplier
+}
+
+func NewPricingUseCase(pr domain.ProductRepository, pcr domain.PriceChangeRepository, si domain.SearchIndex, ep domain.EventPublisher) domain.PricingUseCase {
+	return &pricingUseCase{
+		productRepo: pr,
+		priceRepo:   pcr,
+		applier:     &priceApplier{productRepo: pr, priceRepo: pcr, searchIndex: si, publisher: ep},
+	}
+}
+
+func (uc *pricingUseCase) Schedule(ctx context.Context, productID string, actor domain.Actor, schedule domain.PriceSchedule) (*domain.PriceChange, error) {
+	if !primitive.IsValidObjectID(productID) {
+		return nil, domain.ErrInvalidProductID
+	}
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound
+	}
+
+	if schedule.EffectiveFrom == nil || !schedule.EffectiveFrom.After(time.Now()) {
+		return uc.applier.record(ctx, product, schedule.Price, schedule.Reason, actor)
+	}
+
+	change := &domain.PriceChange{
+		ProductID:     product.ID,
+		Price:         schedule.Price,
+		PreviousPrice: product.Price,
+		EffectiveFrom: *schedule.EffectiveFrom,
+		Status:        domain.PriceChangeScheduled,
+		Reason:        schedule.Reason,
+		ActorID:       actor.ID,
+		ActorEmail:    actor.Email,
+	}
+	if err := uc.priceRepo.Create(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (uc *pricingUseCase) GetHistory(ctx context.Context, productID string, query domain.ListQuery) ([]domain.PriceChange, *domain.Pagination, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidProductID
+	}
+	return uc.priceRepo.FindByProductID(ctx, objectID, query)
+}
+
+func (uc *pricingUseCase) Cancel(ctx context.Context, productID, changeID string) error {
+	change, err := uc.priceRepo.FindByID(ctx, changeID)
+	if err != nil {
+		return err
+	}
+	if change.ProductID.Hex() != productID {
+		return domain.ErrPriceChangeNotFound
+	}
+
+	cancelled, err := uc.priceRepo.MarkStatus(ctx, change.ID, domain.PriceChangeCancelled, time.Now())
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return domain.ErrPriceChangeNotScheduled
+	}
+	return nil
+}
+
+// ApplyDue applies every scheduled change whose effective time has passed.
+// Each change is claimed before it is applied, so concurrent workers never
+// apply the same change twice.
+func (uc *pricingUseCase) ApplyDue(ctx context.Context, now time.Time) (int, error) {
+	applied := 0
+	for {
+		due, err := uc.priceRepo.FindDue(ctx, now, priceChangeBatchSize)
+		if err != nil {
+			return applied, err
+		}
+
+		for i := range due {
+			change := &due[i]
+			claimed, err := uc.priceRepo.MarkStatus(ctx, change.ID, domain.PriceChangeApplied, now)
+			if err != nil {
+				return applied, err
+			}
+			if !claimed {
+				continue
+			}
+
+			product, err := uc.productRepo.FindByID(ctx, change.ProductID.Hex())
+			if err != nil {
+				log.Printf("Skipping price change %s: product %s not found", change.ID.Hex(), change.ProductID.Hex())
+				continue
+			}
+			if err := uc.applier.apply(ctx, product, change, now); err != nil {
+				return applied, err
+			}
+			if err := uc.priceRepo.UpdatePreviousPrice(ctx, change.ID, change.PreviousPrice); err != nil {
+				return applied, err
+			}
+			applied++
+		}
+
+		if len(due) < priceChangeBatchSize {
+			return applied, nil
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/thumbnail"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type productImageUseCase struct {
+	productRepo    domain.ProductRepository
+	blobStore      domain.BlobStore
+	maxImageBytes  int64
+	maxImagePixels int
+	thumbnailSize  int
+}
+
+func NewProductImageUseCase(pr domain.ProductRepository, bs domain.BlobStore, maxImageBytes int64, maxImagePixels int, thumbnailSize int) domain.ProductImageUseCase {
+	return &productImageUseCase{
+		productRepo:    pr,
+		blobStore:      bs,
+		maxImageBytes:  maxImageBytes,
+		maxImagePixels: maxImagePixels,
+		thumbnailSize:  thumbnailSize,
+	}
+}
+
+func (uc *productImageUseCase) Upload(ctx context.Context, productID string, data []byte) (*domain.ProductImage, error) {
+	if int64(len(data)) > uc.maxImageBytes {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, domain.ErrUnsupportedImageType
+	}
+
+	config, _, err := thumbnail.DecodeConfig(data)
+	if err != nil {
+		return nil, domain.ErrUnsupportedImageType
+	}
+	// A small file can still declare huge dimensions, and decoding it for the
+	// thumbnail would allocate all of them.
+	if int64(config.Width)*int64(config.Height) > int64(uc.maxImagePixels) {
+		return nil, domain.ErrImageTooManyPixels
+	}
+
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	thumb, err := thumbnail.Generate(data, uc.thumbnailSize)
+	if err != nil {
+		return nil, domain.ErrUnsupportedImageType
+	}
+
+	imageID := primitive.NewObjectID()
+	image := domain.ProductImage{
+		ID:           imageID,
+		Key:          fmt.Sprintf("products/%s/%s.%s", product.ID.Hex(), imageID.Hex(), extension),
+		ThumbnailKey: fmt.Sprintf("products/%s/%s_thumb.jpg", product.ID.Hex(), imageID.Hex()),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        config.Width,
+		Height:       config.Height,
+		Position:     len(product.Images),
+		CreatedAt:    time.Now(),
+	}
+	image.URL = uc.blobStore.URL(image.Key)
+	image.ThumbnailURL = uc.blobStore.URL(image.ThumbnailKey)
+
+	if err := uc.blobStore.Put(ctx, image.Key, bytes.NewReader(data), image.Size, contentType); err != nil {
+		return nil, err
+	}
+	if err := uc.blobStore.Put(ctx, image.ThumbnailKey, bytes.NewReader(thumb.Data), int64(len(thumb.Data)), "image/jpeg"); err != nil {
+		uc.blobStore.Delete(ctx, image.Key)
+		return nil, err
+	}
+
+	if err := uc.productRepo.UpdateImages(ctx, product.ID, append(product.Images, image)); err != nil {
+		uc.deleteBlobs(ctx, image)
+		return nil, err
+	}
+	return &image, nil
+}
+
+func (uc *productImageUseCase) Reorder(ctx context.Context, productID string, imageIDs []string) ([]domain.ProductImage, error) {
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(imageIDs) != len(product.Images) {
+		return nil, domain.ErrInvalidProductData
+	}
+
+	byID := make(map[string]domain.ProductImage, len(product.Images))
+	for _, image := range product.Images {
+		byID[image.ID.Hex()] = image
+	}
+
+	images := make([]domain.ProductImage, 0, len(imageIDs))
+	for position, id := range imageIDs {
+		image, ok := byID[id]
+		if !ok {
+			return nil, domain.ErrImageNotFound
+		}
+		delete(byID, id)
+		image.Position = position
+		images = append(images, image)
+	}
+
+	if err := uc.productRepo.UpdateImages(ctx, product.ID, images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+func (uc *productImageUseCase) Delete(ctx context.Context, productID, imageID string) error {
+	product, err := uc.findProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	var removed *domain.ProductImage
+	images := make([]domain.ProductImage, 0, len(product.Images))
+	for _, image := range product.Images {
+		if image.ID.Hex() == imageID {
+			match := image
+			removed = &match
+			continue
+		}
+		image.Position = len(images)
+		images = append(images, image)
+	}
+	if removed == nil {
+		return domain.ErrImageNotFound
+	}
+
+	if err := uc.productRepo.UpdateImages(ctx, product.ID, images); err != nil {
+		return err
+	}
+	return uc.deleteBlobs(ctx, *removed)
+}
+
+func (uc *productImageUseCase) findProduct(ctx context.Context, productID string) (*domain.Product, error) {
+	if !primitive.IsValidObjectID(productID) {
+		return nil, domain.ErrInvalidProductID
+	}
+
+	product, err := uc.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, domain.ErrProductNotFound
+	}
+	return product, nil
+}
+
+func (uc *productImageUseCase) deleteBlobs(ctx context.Context, image domain.ProductImage) error {
+	if err := uc.blobStore.Delete(ctx, image.Key); err != nil {
+		return err
+	}
+	return uc.blobStore.Delete(ctx, image.ThumbnailKey)
+}
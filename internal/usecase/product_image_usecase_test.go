@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"play-to-win-api/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductImageUseCase_Upload_RejectsTooManyPixels(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 100, 100))))
+
+	// The repository and blob store are nil: the upload must be refused
+	// before the product is looked up or anything is stored.
+	uc := NewProductImageUseCase(nil, nil, 5<<20, 100*99, 320)
+
+	image, err := uc.Upload(context.Background(), "64b7f0c2a1b2c3d4e5f60718", buf.Bytes())
+
+	assert.Nil(t, image)
+	assert.Equal(t, domain.ErrImageTooManyPixels, err)
+}
@@ -12,17 +12,36 @@ import (
 )
 
 type productUseCase struct {
-	productRepo domain.ProductRepository
+	productRepo  domain.ProductRepository
+	categoryRepo domain.CategoryRepository
+	searchIndex  domain.SearchIndex
+	prices       *priceApplier
 }
 
-func NewProductUseCase(pr domain.ProductRepository) domain.ProductUseCase {
+func NewProductUseCase(pr domain.ProductRepository, cr domain.CategoryRepository, si domain.SearchIndex, pcr domain.PriceChangeRepository, ep domain.EventPublisher) domain.ProductUseCase {
 	return &productUseCase{
-		productRepo: pr,
+		productRepo:  pr,
+		categoryRepo: cr,
+		searchIndex:  si,
+		prices:       &priceApplier{productRepo: pr, priceRepo: pcr, searchIndex: si, publisher: ep},
 	}
 }
 
 func (uc *productUseCase) Create(ctx context.Context, product *domain.Product) error {
-	return uc.productRepo.Create(ctx, product)
+	product.DeletedAt = nil
+	if err := product.ValidatePrices(); err != nil {
+		return err
+	}
+	if !product.TaxClass.Valid() {
+		return domain.ErrInvalidTaxClass
+	}
+	if err := uc.assignSlug(ctx, product, nil); err != nil {
+		return err
+	}
+	if err := uc.productRepo.Create(ctx, product); err != nil {
+		return err
+	}
+	return uc.searchIndex.Index(ctx, product)
 }
 
 func (uc *productUseCase) GetByID(ctx context.Context, id string) (*domain.Product, error) {
@@ -40,12 +59,267 @@ func (uc *productUseCase) GetByID(ctx context.Context, id string) (*domain.Produ
 	return product, nil
 }
 
-func (uc *productUseCase) GetAll(ctx context.Context) ([]domain.Product, error) {
-	return uc.productRepo.FindAll(ctx)
+func (uc *productUseCase) GetBySlug(ctx context.Context, slug string) (*domain.Product, error) {
+	return uc.productRepo.FindBySlug(ctx, slug)
+}
+
+func (uc *productUseCase) assignSlug(ctx context.Context, product, existing *domain.Product) error {
+	s := slugged{id: product.ID, name: product.Name, fallback: "product", requested: product.Slug}
+	if existing != nil {
+		s.current, s.previous = existing.Slug, existing.PreviousSlugs
+	}
+
+	var err error
+	product.Slug, product.PreviousSlugs, err = s.resolve(ctx, uc.productRepo.SlugTaken)
+	return err
+}
+
+// EnsureSlugs gives every product created before slugs existed one derived
+// from its name.
+func (uc *productUseCase) EnsureSlugs(ctx context.Context) (int, error) {
+	var missing []domain.Product
+	err := uc.productRepo.Each(ctx, func(product *domain.Product) error {
+		if product.Slug == "" {
+			missing = append(missing, *product)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range missing {
+		product := &missing[i]
+		if err := uc.assignSlug(ctx, product, nil); err != nil {
+			return i, err
+		}
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			return i, err
+		}
+		if err := uc.searchIndex.Index(ctx, product); err != nil {
+			return i, err
+		}
+	}
+	return len(missing), nil
+}
+
+func (uc *productUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	if query.Filter.CategoryID != "" {
+		includeDescendants := query.Filter.IncludeDescendants == nil || *query.Filter.IncludeDescendants
+		categoryIDs, err := uc.categoryIDs(ctx, query.Filter.CategoryID, includeDescendants)
+		if err != nil {
+			return nil, nil, err
+		}
+		query.Filter.CategoryIDs = categoryIDs
+	}
+
+	return uc.productRepo.FindAll(ctx, query)
+}
+
+func (uc *productUseCase) categoryIDs(ctx context.Context, categoryID string, includeDescendants bool) ([]primitive.ObjectID, error) {
+	if !primitive.IsValidObjectID(categoryID) {
+		return nil, domain.ErrInvalidCategoryID
+	}
+
+	category, err := uc.categoryRepo.FindByID(ctx, categoryID)
+	if err != nil {
+		return nil, domain.ErrCategoryNotFound
+	}
+	if !includeDescendants {
+		return []primitive.ObjectID{category.ID}, nil
+	}
+
+	descendants, err := uc.categoryRepo.FindByPathPrefix(ctx, category.SubtreePath())
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := []primitive.ObjectID{category.ID}
+	for _, descendant := range descendants {
+		categoryIDs = append(categoryIDs, descendant.ID)
+	}
+	return categoryIDs, nil
+}
+
+func (uc *productUseCase) Search(ctx context.Context, query domain.ProductSearchQuery) (*domain.ProductSearchResult, error) {
+	if query.Limit <= 0 {
+		query.Limit = domain.DefaultListLimit
+	}
+	if query.Limit > domain.MaxListLimit {
+		query.Limit = domain.MaxListLimit
+	}
+	if query.CategoryID != "" {
+		categoryIDs, err := uc.categoryIDs(ctx, query.CategoryID, true)
+		if err != nil {
+			return nil, err
+		}
+		query.CategoryIDs = categoryIDs
+	}
+
+	result, err := uc.searchIndex.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.labelCategoryFacets(ctx, result.Facets.Categories); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (uc *productUseCase) Suggest(ctx context.Context, prefix string, limit int) ([]string, error) {
+	if limit <= 0 || limit > domain.MaxListLimit {
+		limit = 10
+	}
+	return uc.searchIndex.Suggest(ctx, prefix, limit)
+}
+
+func (uc *productUseCase) labelCategoryFacets(ctx context.Context, facets []domain.FacetCount) error {
+	if len(facets) == 0 {
+		return nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(facets))
+	for _, facet := range facets {
+		if id, err := primitive.ObjectIDFromHex(facet.Value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	categories, err := uc.categoryRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category.ID.Hex()] = category.Name
+	}
+	for i := range facets {
+		facets[i].Label = names[facets[i].Value]
+	}
+	return nil
 }
 
 func (uc *productUseCase) Update(ctx context.Context, product *domain.Product) error {
-	return uc.productRepo.Update(ctx, product)
+	if err := product.ValidatePrices(); err != nil {
+		return err
+	}
+	if !product.TaxClass.Valid() {
+		return domain.ErrInvalidTaxClass
+	}
+	existing, err := uc.GetByID(ctx, product.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	product.Variants = nil
+	product.Images = nil
+	product.RatingAverage = existing.RatingAverage
+	product.RatingCount = existing.RatingCount
+	product.RatingSum = existing.RatingSum
+	product.Stock = existing.Stock
+	product.Sold = existing.Sold
+	product.DeletedAt = nil
+	if err := uc.assignSlug(ctx, product, existing); err != nil {
+		return err
+	}
+
+	price := product.Price
+	product.Price = existing.Price
+	product.WasPrice = existing.WasPrice
+
+	if err := uc.productRepo.Update(ctx, product); err != nil {
+		return err
+	}
+	product.Variants = existing.Variants
+	product.Images = existing.Images
+
+	if price != existing.Price {
+		_, err := uc.prices.record(ctx, product, price, "product update", domain.Actor{})
+		return err
+	}
+	return uc.searchIndex.Index(ctx, product)
+}
+
+func (uc *productUseCase) AddVariant(ctx context.Context, productID string, variant *domain.ProductVariant) error {
+	product, err := uc.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if err := uc.ensureUniqueSKU(ctx, variant.SKU, primitive.NilObjectID); err != nil {
+		return err
+	}
+
+	variant.ID = primitive.NewObjectID()
+	return uc.saveVariants(ctx, product, append(product.Variants, *variant))
+}
+
+func (uc *productUseCase) UpdateVariant(ctx context.Context, productID string, variant *domain.ProductVariant) error {
+	product, err := uc.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	existing := product.Variant(variant.ID)
+	if existing == nil {
+		return domain.ErrVariantNotFound
+	}
+	if err := uc.ensureUniqueSKU(ctx, variant.SKU, variant.ID); err != nil {
+		return err
+	}
+
+	variant.Stock = existing.Stock
+	*existing = *variant
+	return uc.saveVariants(ctx, product, product.Variants)
+}
+
+func (uc *productUseCase) DeleteVariant(ctx context.Context, productID, variantID string) error {
+	objectID, err := primitive.ObjectIDFromHex(variantID)
+	if err != nil {
+		return domain.ErrInvalidVariantID
+	}
+
+	product, err := uc.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if product.Variant(objectID) == nil {
+		return domain.ErrVariantNotFound
+	}
+
+	variants := make([]domain.ProductVariant, 0, len(product.Variants)-1)
+	for _, variant := range product.Variants {
+		if variant.ID != objectID {
+			variants = append(variants, variant)
+		}
+	}
+	return uc.saveVariants(ctx, product, variants)
+}
+
+func (uc *productUseCase) ensureUniqueSKU(ctx context.Context, sku string, variantID primitive.ObjectID) error {
+	owner, err := uc.productRepo.FindByVariantSKU(ctx, sku)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, variant := range owner.Variants {
+		if variant.SKU == sku && variant.ID != variantID {
+			return domain.ErrDuplicateSKU
+		}
+	}
+	return nil
+}
+
+func (uc *productUseCase) saveVariants(ctx context.Context, product *domain.Product, variants []domain.ProductVariant) error {
+	if err := uc.productRepo.UpdateVariants(ctx, product.ID, variants); err != nil {
+		return err
+	}
+	product.Variants = variants
+	return uc.searchIndex.Index(ctx, product)
 }
 
 func (uc *productUseCase) Delete(ctx context.Context, id string) error {
@@ -53,5 +327,27 @@ func (uc *productUseCase) Delete(ctx context.Context, id string) error {
 		return domain.ErrInvalidProductID
 	}
 
-	return uc.productRepo.Delete(ctx, id)
-}
\ No newline at end of file
+	if err := uc.productRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return uc.searchIndex.Remove(ctx, id)
+}
+
+func (uc *productUseCase) Restore(ctx context.Context, id string) (*domain.Product, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, domain.ErrInvalidProductID
+	}
+
+	if err := uc.productRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	product, err := uc.productRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return product, uc.searchIndex.Index(ctx, product)
+}
+
+func (uc *productUseCase) GetArchived(ctx context.Context, query domain.ListQuery) ([]domain.Product, *domain.Pagination, error) {
+	return uc.productRepo.FindArchived(ctx, query)
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type recommendationUseCase struct {
+	recommendationRepo domain.RecommendationRepository
+	cartRepo           domain.CartRepository
+	cartItemRepo       domain.CartItemRepository
+	settings           domain.RecommendationSettings
+}
+
+func NewRecommendationUseCase(rr domain.RecommendationRepository, cartRepo domain.CartRepository, cir domain.CartItemRepository, settings domain.RecommendationSettings) domain.RecommendationUseCase {
+	return &recommendationUseCase{
+		recommendationRepo: rr,
+		cartRepo:           cartRepo,
+		cartItemRepo:       cir,
+		settings:           settings,
+	}
+}
+
+func (uc *recommendationUseCase) ForProduct(ctx context.Context, productID string, limit int) ([]domain.Product, error) {
+	id, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, domain.ErrInvalidProductID
+	}
+	return uc.recommendationRepo.FindRelated(ctx, []primitive.ObjectID{id}, recommendationLimit(limit))
+}
+
+func (uc *recommendationUseCase) ForCart(ctx context.Context, userID string, isAdmin bool, cartID string, limit int) ([]domain.Product, error) {
+	if _, err := primitive.ObjectIDFromHex(cartID); err != nil {
+		return nil, domain.ErrInvalidCartID
+	}
+	cart, err := uc.cartRepo.FindByID(ctx, cartID)
+	if err != nil || (!isAdmin && cart.User.ID.Hex() != userID) {
+		return nil, domain.ErrCartNotFound
+	}
+
+	items, err := uc.cartItemRepo.FindByCartID(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return []domain.Product{}, nil
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(items))
+	ids := make([]primitive.ObjectID, 0, len(items))
+	for _, item := range items {
+		if !seen[item.ProductId] {
+			seen[item.ProductId] = true
+			ids = append(ids, item.ProductId)
+		}
+	}
+	return uc.recommendationRepo.FindRelated(ctx, ids, recommendationLimit(limit))
+}
+
+func (uc *recommendationUseCase) Refresh(ctx context.Context) (int64, error) {
+	return uc.recommendationRepo.Rebuild(ctx, uc.settings)
+}
+
+func recommendationLimit(limit int) int {
+	if limit <= 0 || limit > domain.MaxListLimit {
+		return 10
+	}
+	return limit
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type reviewUseCase struct {
+	reviewRepo  domain.ReviewRepository
+	productRepo domain.ProductRepository
+	orderRepo   domain.OrderRepository
+	userRepo    domain.UserRepository
+}
+
+func NewReviewUseCase(rr domain.ReviewRepository, pr domain.ProductRepository, or domain.OrderRepository, ur domain.UserRepository) domain.ReviewUseCase {
+	return &reviewUseCase{
+		reviewRepo:  rr,
+		productRepo: pr,
+		orderRepo:   or,
+		userRepo:    ur,
+	}
+}
+
+func (uc *reviewUseCase) Create(ctx context.Context, userID string, review *domain.Review) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	if review.ProductID.IsZero() {
+		return domain.ErrInvalidProductID
+	}
+	if _, err := uc.productRepo.FindByID(ctx, review.ProductID.Hex()); err != nil {
+		return domain.ErrProductNotFound
+	}
+
+	purchased, err := uc.orderRepo.HasPurchased(ctx, userObjectID, review.ProductID)
+	if err != nil {
+		return err
+	}
+	if !purchased {
+		return domain.ErrReviewNotPurchased
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userObjectID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	review.ID = primitive.NilObjectID
+	review.UserID = userObjectID
+	review.UserName = user.Name
+	review.Status = domain.ReviewStatusPublished
+	review.ModerationNote = ""
+	review.ModeratedAt = nil
+
+	if err := uc.reviewRepo.Create(ctx, review); err != nil {
+		return err
+	}
+	return uc.productRepo.AdjustRating(ctx, review.ProductID, 1, review.Rating)
+}
+
+func (uc *reviewUseCase) GetByProductID(ctx context.Context, productID string, query domain.ListQuery) ([]domain.Review, *domain.Pagination, error) {
+	objectID, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidProductID
+	}
+
+	query.Filter.Status = string(domain.ReviewStatusPublished)
+	return uc.reviewRepo.FindByProductID(ctx, objectID, query)
+}
+
+func (uc *reviewUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Review, *domain.Pagination, error) {
+	if query.Filter.Status != "" && !domain.ReviewStatus(query.Filter.Status).Valid() {
+		return nil, nil, domain.ErrInvalidReviewStatus
+	}
+	return uc.reviewRepo.FindAll(ctx, query)
+}
+
+func (uc *reviewUseCase) Update(ctx context.Context, userID string, review *domain.Review) error {
+	existing, err := uc.reviewRepo.FindByID(ctx, review.ID.Hex())
+	if err != nil {
+		return err
+	}
+	if existing.UserID.Hex() != userID {
+		return domain.ErrReviewForbidden
+	}
+	if !review.ProductID.IsZero() && review.ProductID != existing.ProductID {
+		return domain.ErrReviewNotFound
+	}
+
+	delta := review.Rating - existing.Rating
+	existing.Rating = review.Rating
+	existing.Title = review.Title
+	existing.Body = review.Body
+
+	if err := uc.reviewRepo.Update(ctx, existing); err != nil {
+		return err
+	}
+	*review = *existing
+
+	if existing.Published() && delta != 0 {
+		return uc.productRepo.AdjustRating(ctx, existing.ProductID, 0, delta)
+	}
+	return nil
+}
+
+func (uc *reviewUseCase) Moderate(ctx context.Context, id string, moderation domain.ReviewModeration) (*domain.Review, error) {
+	if !moderation.Status.Valid() {
+		return nil, domain.ErrInvalidReviewStatus
+	}
+
+	review, err := uc.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	wasPublished := review.Published()
+	now := time.Now()
+	review.Status = moderation.Status
+	review.ModerationNote = moderation.Note
+	review.ModeratedAt = &now
+
+	if err := uc.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case wasPublished && !review.Published():
+		err = uc.productRepo.AdjustRating(ctx, review.ProductID, -1, -review.Rating)
+	case !wasPublished && review.Published():
+		err = uc.productRepo.AdjustRating(ctx, review.ProductID, 1, review.Rating)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (uc *reviewUseCase) Delete(ctx context.Context, userID string, isAdmin bool, id string) error {
+	review, err := uc.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !isAdmin && review.UserID.Hex() != userID {
+		return domain.ErrReviewForbidden
+	}
+
+	if err := uc.reviewRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if review.Published() {
+		return uc.productRepo.AdjustRating(ctx, review.ProductID, -1, -review.Rating)
+	}
+	return nil
+}
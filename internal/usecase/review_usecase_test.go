@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// placedOrders answers HasPurchased from the orders it holds.
+type placedOrders struct {
+	domain.OrderRepository
+	orders []domain.Order
+}
+
+func (r *placedOrders) HasPurchased(ctx context.Context, userID, productID primitive.ObjectID) (bool, error) {
+	for _, order := range r.orders {
+		if order.UserID != userID {
+			continue
+		}
+		for _, item := range order.Items {
+			if item.ProductID == productID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+type existingProducts struct {
+	domain.ProductRepository
+}
+
+func (r *existingProducts) FindByID(ctx context.Context, id string) (*domain.Product, error) {
+	objectID, _ := primitive.ObjectIDFromHex(id)
+	return &domain.Product{ID: objectID}, nil
+}
+
+func TestReviewUseCase_Create_RejectsProductOnlyInCart(t *testing.T) {
+	userID := primitive.NewObjectID()
+	inCart, ordered := primitive.NewObjectID(), primitive.NewObjectID()
+
+	// The user has inCart sitting in a cart, but only ever ordered another
+	// product. Reviews are checked against orders, so carts aren't consulted.
+	orders := &placedOrders{orders: []domain.Order{
+		{UserID: userID, Items: []domain.OrderItem{{ProductID: ordered}}},
+	}}
+	uc := NewReviewUseCase(nil, &existingProducts{}, orders, nil)
+
+	err := uc.Create(context.Background(), userID.Hex(), &domain.Review{ProductID: inCart, Rating: 5, Body: "Great"})
+
+	assert.Equal(t, domain.ErrReviewNotPurchased, err)
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"play-to-win-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type segmentUseCase struct {
+	segmentRepo  domain.SegmentRepository
+	campaignRepo domain.CampaignRepository
+	userRepo     domain.UserRepository
+	orderRepo    domain.OrderRepository
+}
+
+func NewSegmentUseCase(sr domain.SegmentRepository, cr domain.CampaignRepository, ur domain.UserRepository, or domain.OrderRepository) domain.SegmentUseCase {
+	return &segmentUseCase{
+		segmentRepo:  sr,
+		campaignRepo: cr,
+		userRepo:     ur,
+		orderRepo:    or,
+	}
+}
+
+func (uc *segmentUseCase) Create(ctx context.Context, segment *domain.Segment) error {
+	if err := validateSegmentRules(segment.Rules); err != nil {
+		return err
+	}
+	return uc.segmentRepo.Create(ctx, segment)
+}
+
+func (uc *segmentUseCase) GetByID(ctx context.Context, id string) (*domain.Segment, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, domain.ErrInvalidSegmentID
+	}
+	return uc.segmentRepo.FindByID(ctx, objectID)
+}
+
+func (uc *segmentUseCase) GetAll(ctx context.Context, query domain.ListQuery) ([]domain.Segment, *domain.Pagination, error) {
+	return uc.segmentRepo.FindAll(ctx, query)
+}
+
+func (uc *segmentUseCase) Update(ctx context.Context, segment *domain.Segment) error {
+	if err := validateSegmentRules(segment.Rules); err != nil {
+		return err
+	}
+	return uc.segmentRepo.Update(ctx, segment)
+}
+
+func (uc *segmentUseCase) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return domain.ErrInvalidSegmentID
+	}
+
+	inUse, err := uc.campaignRepo.TargetsSegment(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return domain.ErrSegmentInUse
+	}
+	return uc.segmentRepo.Delete(ctx, objectID)
+}
+
+func (uc *segmentUseCase) Profile(ctx context.Context, userID primitive.ObjectID) (*domain.CustomerProfile, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	orders, err := uc.orderRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CustomerProfile{
+		UserID:       user.ID,
+		Role:         user.Role,
+		RegisteredAt: user.CreatedAt,
+		Orders:       orders,
+		Points:       user.Points,
+	}, nil
+}
+
+func (uc *segmentUseCase) Eligible(ctx context.Context, profile *domain.CustomerProfile, segmentIDs []primitive.ObjectID) (bool, error) {
+	if len(segmentIDs) == 0 {
+		return true, nil
+	}
+	if profile == nil {
+		return false, nil
+	}
+
+	segments, err := uc.segmentRepo.FindByIDs(ctx, segmentIDs)
+	if err != nil {
+		return false, err
+	}
+	for i := range segments {
+		if segments[i].Matches(profile) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func validateSegmentRules(rules []domain.SegmentRule) error {
+	if len(rules) == 0 {
+		return domain.ErrInvalidSegmentRule
+	}
+	for _, rule := range rules {
+		if !rule.Valid() {
+			return domain.ErrInvalidSegmentRule
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"play-to-win-api/internal/domain"
+	"play-to-win-api/pkg/slug"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxSlugAttempts = 100
+
+type slugChecker func(ctx context.Context, slug string, exclude primitive.ObjectID) (bool, error)
+
+// slugged carries the slug state of a product or category through an edit.
+type slugged struct {
+	id        primitive.ObjectID
+	name      string
+	fallback  string
+	requested string
+	current   string
+	previous  []string
+}
+
+// resolve returns the slug the entity should carry and the old slugs that
+// keep redirecting to it. An unchanged or empty request keeps the current
+// slug so renaming an entity never breaks its URL.
+func (s slugged) resolve(ctx context.Context, taken slugChecker) (string, []string, error) {
+	requested := ""
+	if s.requested != "" {
+		requested = slug.Make(s.requested)
+		if requested == "" {
+			return "", nil, domain.ErrInvalidSlug
+		}
+	}
+
+	if s.current != "" && (requested == "" || requested == s.current) {
+		return s.current, s.previous, nil
+	}
+
+	next, err := s.unique(ctx, taken, requested)
+	if err != nil {
+		return "", nil, err
+	}
+	if s.current == "" {
+		return next, s.previous, nil
+	}
+
+	previous := make([]string, 0, len(s.previous)+1)
+	for _, old := range s.previous {
+		if old != next {
+			previous = append(previous, old)
+		}
+	}
+	return next, append(previous, s.current), nil
+}
+
+func (s slugged) unique(ctx context.Context, taken slugChecker, requested string) (string, error) {
+	if requested != "" {
+		used, err := taken(ctx, requested, s.id)
+		if err != nil {
+			return "", err
+		}
+		if used {
+			return "", domain.ErrSlugTaken
+		}
+		return requested, nil
+	}
+
+	base := slug.Make(s.name)
+	if base == "" {
+		base = s.fallback
+	}
+	for n := 1; n <= maxSlugAttempts; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+		used, err := taken(ctx, candidate, s.id)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return candidate, nil
+		}
+	}
+	return "", domain.ErrSlugTaken
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import "play-to-win-api/internal/domain"
+
+type vatCalculator struct {
+	roundingMode domain.RoundingMode
+}
+
+func NewVATCalculator(mode domain.RoundingMode) domain.TaxCalculator {
+	return &vatCalculator{roundingMode: mode}
+}
+
+// Calculate rounds once per class, on the sum of its lines, the way a VAT
+// invoice shows it. Inclusive tax is taken out of the lines' amounts and
+// exclusive tax is charged on them.
+func (c *vatCalculator) Calculate(region domain.TaxRegion, lines []domain.TaxableLine) domain.TaxBreakdown {
+	breakdown := domain.TaxBreakdown{
+		Region:    region.Code,
+		Inclusive: region.Inclusive,
+		Lines:     []domain.TaxLine{},
+	}
+
+	var classes []domain.TaxClass
+	amounts := map[domain.TaxClass]domain.Money{}
+	for _, line := range lines {
+		class := line.Class.OrStandard()
+		if _, ok := amounts[class]; !ok {
+			classes = append(classes, class)
+		}
+		amounts[class] += line.Amount
+	}
+
+	for _, class := range classes {
+		amount := amounts[class]
+		rate, taxed := region.Rate(class)
+		taxLine := domain.TaxLine{Class: class, Rate: rate, Base: amount}
+		if taxed {
+			if region.Inclusive {
+				taxLine.Tax = amount.IncludedTax(rate, c.roundingMode)
+				taxLine.Base = amount - taxLine.Tax
+			} else {
+				taxLine.Tax = amount.Percent(rate, c.roundingMode)
+			}
+		}
+		breakdown.Lines = append(breakdown.Lines, taxLine)
+		breakdown.Total += taxLine.Tax
+	}
+	return breakdown
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"testing"
+
+	"play-to-win-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var taxableLines = []domain.TaxableLine{
+	{Amount: 500_00},
+	{Amount: 500_00, Class: domain.TaxClassStandard},
+	{Amount: 200_00, Class: domain.TaxClassExempt},
+	{Amount: 100_00, Class: domain.TaxClassZero},
+}
+
+func TestVATCalculator_Inclusive(t *testing.T) {
+	breakdown := NewVATCalculator(domain.RoundHalfEven).Calculate(domain.DefaultTaxRegion(), taxableLines)
+
+	assert.Equal(t, []domain.TaxLine{
+		{Class: domain.TaxClassStandard, Rate: 7, Base: 934_58, Tax: 65_42},
+		{Class: domain.TaxClassExempt, Base: 200_00},
+		{Class: domain.TaxClassZero, Base: 100_00},
+	}, breakdown.Lines)
+	assert.Equal(t, domain.Money(65_42), breakdown.Total)
+	assert.Equal(t, domain.Money(0), breakdown.Added())
+}
+
+func TestVATCalculator_Exclusive(t *testing.T) {
+	region := domain.TaxRegion{Code: "US-CA", Rates: map[domain.TaxClass]float64{domain.TaxClassStandard: 7.25}}
+	breakdown := NewVATCalculator(domain.RoundHalfUp).Calculate(region, taxableLines)
+
+	assert.Equal(t, domain.TaxLine{Class: domain.TaxClassStandard, Rate: 7.25, Base: 1000_00, Tax: 72_50}, breakdown.Lines[0])
+	assert.Equal(t, domain.Money(72_50), breakdown.Total)
+	assert.Equal(t, domain.Money(72_50), breakdown.Added())
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"play-to-win-api/internal/domain"
+	"strings"
+)
+
+type taxUseCase struct {
+	taxRegionRepo domain.TaxRegionRepository
+	calculator    domain.TaxCalculator
+	defaultRegion string
+}
+
+func NewTaxUseCase(tr domain.TaxRegionRepository, calculator domain.TaxCalculator, defaultRegion string) domain.TaxUseCase {
+	return &taxUseCase{
+		taxRegionRepo: tr,
+		calculator:    calculator,
+		defaultRegion: strings.ToUpper(defaultRegion),
+	}
+}
+
+// GetRegions lists the configured regions, with the built-in default while
+// it has not been overridden.
+func (uc *taxUseCase) GetRegions(ctx context.Context) ([]domain.TaxRegion, error) {
+	regions, err := uc.taxRegionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fallback := domain.DefaultTaxRegion()
+	for _, region := range regions {
+		if region.Code == fallback.Code {
+			return regions, nil
+		}
+	}
+	return append([]domain.TaxRegion{fallback}, regions...), nil
+}
+
+func (uc *taxUseCase) SetRegion(ctx context.Context, region *domain.TaxRegion) error {
+	region.Code = strings.ToUpper(region.Code)
+	if err := region.Validate(); err != nil {
+		return err
+	}
+	return uc.taxRegionRepo.Upsert(ctx, region)
+}
+
+func (uc *taxUseCase) DeleteRegion(ctx context.Context, code string) error {
+	code = strings.ToUpper(code)
+	if !domain.ValidTaxRegionCode(code) {
+		return domain.ErrInvalidTaxRegion
+	}
+	return uc.taxRegionRepo.Delete(ctx, code)
+}
+
+func (uc *taxUseCase) Region(ctx context.Context, code string) (*domain.TaxRegion, error) {
+	code = strings.ToUpper(code)
+	if code == "" {
+		code = uc.defaultRegion
+	}
+	region, err := uc.taxRegionRepo.FindByCode(ctx, code)
+	if errors.Is(err, domain.ErrTaxRegionNotFound) {
+		if fallback := domain.DefaultTaxRegion(); code == fallback.Code {
+			return &fallback, nil
+		}
+		return nil, domain.ErrUnsupportedTaxRegion
+	}
+	if err != nil {
+		return nil, err
+	}
+	return region, nil
+}
+
+// Calculate charges tax on the items after the discounts allocated to them.
+func (uc *taxUseCase) Calculate(ctx context.Context, code string, items []domain.CartItem, discounts []domain.DiscountLine) (*domain.TaxBreakdown, error) {
+	region, err := uc.Region(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	breakdown := uc.calculator.Calculate(*region, domain.TaxableLines(items, discounts))
+	return &breakdown, nil
+}
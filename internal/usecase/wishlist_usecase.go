@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"play-to-win-api/internal/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type wishlistUseCase struct {
+	wishlistRepo     domain.WishlistRepository
+	productRepo      domain.ProductRepository
+	cartRepo         domain.CartRepository
+	notificationRepo domain.NotificationRepository
+	cartItemUseCase  domain.CartItemUseCase
+}
+
+func NewWishlistUseCase(wr domain.WishlistRepository, pr domain.ProductRepository, cartRepo domain.CartRepository, nr domain.NotificationRepository, ciu domain.CartItemUseCase) domain.WishlistUseCase {
+	return &wishlistUseCase{
+		wishlistRepo:     wr,
+		productRepo:      pr,
+		cartRepo:         cartRepo,
+		notificationRepo: nr,
+		cartItemUseCase:  ciu,
+	}
+}
+
+func (uc *wishlistUseCase) Add(ctx context.Context, userID string, item *domain.WishlistItem) error {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrInvalidUserID
+	}
+	if item.ProductID.IsZero() {
+		return domain.ErrInvalidProductID
+	}
+
+	product, err := uc.productRepo.FindByID(ctx, item.ProductID.Hex())
+	if err != nil {
+		return domain.ErrProductNotFound
+	}
+	var variant *domain.ProductVariant
+	if item.VariantID != nil {
+		if variant = product.Variant(*item.VariantID); variant == nil {
+			return domain.ErrVariantNotFound
+		}
+	}
+
+	*item = domain.WishlistItem{
+		UserID:            userObjectID,
+		ProductID:         product.ID,
+		VariantID:         item.VariantID,
+		NotifyPriceDrop:   item.NotifyPriceDrop,
+		NotifyBackInStock: item.NotifyBackInStock,
+		PriceWhenAdded:    product.PriceFor(variant),
+	}
+	if err := uc.wishlistRepo.Create(ctx, item); err != nil {
+		return err
+	}
+
+	saved, err := uc.wishlistRepo.FindByID(ctx, userObjectID, item.ID)
+	if err != nil {
+		return err
+	}
+	*item = *saved
+	return nil
+}
+
+func (uc *wishlistUseCase) GetByUserID(ctx context.Context, userID string, query domain.ListQuery) ([]domain.WishlistItem, *domain.Pagination, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, nil, domain.ErrInvalidUserID
+	}
+	return uc.wishlistRepo.FindByUserID(ctx, userObjectID, query)
+}
+
+func (uc *wishlistUseCase) UpdatePreferences(ctx context.Context, userID, id string, prefs domain.WishlistPreferences) error {
+	userObjectID, itemID, err := wishlistIDs(userID, id)
+	if err != nil {
+		return err
+	}
+	return uc.wishlistRepo.UpdatePreferences(ctx, userObjectID, itemID, prefs)
+}
+
+func (uc *wishlistUseCase) Remove(ctx context.Context, userID, id string) error {
+	userObjectID, itemID, err := wishlistIDs(userID, id)
+	if err != nil {
+		return err
+	}
+	return uc.wishlistRepo.Delete(ctx, userObjectID, itemID)
+}
+
+// MoveToCart adds the saved product to one of the user's carts through the
+// regular cart item flow, so stock and price checks apply, then drops it from
+// the wishlist.
+func (uc *wishlistUseCase) MoveToCart(ctx context.Context, userID, id string, move domain.WishlistMove) (*domain.CartItem, error) {
+	userObjectID, itemID, err := wishlistIDs(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := uc.wishlistRepo.FindByID(ctx, userObjectID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := uc.cartRepo.FindByID(ctx, move.CartID)
+	if err != nil || cart.User.ID != userObjectID {
+		return nil, domain.ErrCartNotFound
+	}
+
+	quantity := move.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	cartItem := &domain.CartItem{
+		CartId:    cart.ID,
+		ProductId: item.ProductID,
+		VariantId: item.VariantID,
+		Quantity:  quantity,
+	}
+
+	if err := uc.cartItemUseCase.Create(ctx, cartItem); err != nil {
+		return nil, err
+	}
+	if err := uc.wishlistRepo.Delete(ctx, userObjectID, itemID); err != nil {
+		return nil, err
+	}
+	return cartItem, nil
+}
+
+// NotifyPriceDrop tells watchers when the price they would pay falls below
+// the last price they were told about, so repeated small drops after a big
+// one do not spam them.
+func (uc *wishlistUseCase) NotifyPriceDrop(ctx context.Context, change domain.PriceChanged) error {
+	if change.Price >= change.PreviousPrice {
+		return nil
+	}
+
+	watchers, err := uc.wishlistRepo.FindPriceWatchers(ctx, change.ProductID)
+	if err != nil || len(watchers) == 0 {
+		return err
+	}
+	product, err := uc.productRepo.FindByID(ctx, change.ProductID.Hex())
+	if err != nil {
+		return err
+	}
+
+	var notifications []domain.Notification
+	for _, watcher := range watchers {
+		var variant *domain.ProductVariant
+		if watcher.VariantID != nil {
+			if variant = product.Variant(*watcher.VariantID); variant == nil {
+				continue
+			}
+		}
+		price := product.PriceFor(variant)
+		if price >= watcher.PriceDropReference() {
+			continue
+		}
+
+		if err := uc.wishlistRepo.SetLastNotifiedPrice(ctx, watcher.ID, price); err != nil {
+			return err
+		}
+		notifications = append(notifications, domain.Notification{
+			UserID:    watcher.UserID,
+			Type:      domain.NotificationPriceDrop,
+			ProductID: product.ID,
+			VariantID: watcher.VariantID,
+			Message:   fmt.Sprintf("%s is now %s, down from %s", product.Name, price, watcher.PriceDropReference()),
+		})
+	}
+	return uc.notificationRepo.CreateMany(ctx, notifications)
+}
+
+func (uc *wishlistUseCase) NotifyBackInStock(ctx context.Context, restock domain.BackInStock) error {
+	watchers, err := uc.wishlistRepo.FindStockWatchers(ctx, restock.ProductID, restock.VariantID)
+	if err != nil {
+		return err
+	}
+
+	notifications := make([]domain.Notification, 0, len(watchers))
+	for _, watcher := range watchers {
+		notifications = append(notifications, domain.Notification{
+			UserID:    watcher.UserID,
+			Type:      domain.NotificationBackInStock,
+			ProductID: restock.ProductID,
+			VariantID: restock.VariantID,
+			Message:   fmt.Sprintf("%s is back in stock", restock.ProductName),
+		})
+	}
+	return uc.notificationRepo.CreateMany(ctx, notifications)
+}
+
+func wishlistIDs(userID, id string) (primitive.ObjectID, primitive.ObjectID, error) {
+	userObjectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, domain.ErrInvalidUserID
+	}
+	itemID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, domain.ErrInvalidWishlistID
+	}
+	return userObjectID, itemID, nil
+}
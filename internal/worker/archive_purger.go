@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"time"
+)
+
+const archivePurgerLock = "archive-purger"
+
+// ArchivePurger permanently deletes long-archived documents. Every replica
+// runs one, but only the holder of the leader lock does any work.
+type ArchivePurger struct {
+	purgers   map[string]domain.ArchivePurger
+	lock      domain.LeaderLock
+	holder    string
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewArchivePurger(purgers map[string]domain.ArchivePurger, lock domain.LeaderLock, holder string, retention, interval time.Duration) *ArchivePurger {
+	return &ArchivePurger{purgers: purgers, lock: lock, holder: holder, retention: retention, interval: interval}
+}
+
+// Run permanently deletes documents archived for longer than the retention
+// period on every tick until ctx is cancelled, then hands the lock back so
+// another replica can take over immediately.
+func (w *ArchivePurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := w.lock.Release(context.Background(), archivePurgerLock, w.holder); err != nil {
+			log.Printf("Archive purger: releasing lock: %v", err)
+		}
+	}()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ArchivePurger) tick(ctx context.Context) {
+	leader, err := w.lock.Acquire(ctx, archivePurgerLock, w.holder, 3*w.interval)
+	if err != nil {
+		log.Printf("Archive purger: acquiring lock: %v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	before := time.Now().Add(-w.retention)
+	for name, purger := range w.purgers {
+		purged, err := purger.PurgeArchived(ctx, before)
+		if err != nil {
+			log.Printf("Archive purger: %s: %v", name, err)
+			continue
+		}
+		if purged > 0 {
+			log.Printf("Archive purger: purged %d archived %s", purged, name)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"time"
+)
+
+const campaignSchedulerLock = "campaign-scheduler"
+
+// CampaignScheduler starts and ends campaigns at their window boundaries. Every
+// replica runs one, but only the holder of the leader lock does any work.
+type CampaignScheduler struct {
+	campaigns domain.CampaignUseCase
+	lock      domain.LeaderLock
+	holder    string
+	interval  time.Duration
+}
+
+func NewCampaignScheduler(campaigns domain.CampaignUseCase, lock domain.LeaderLock, holder string, interval time.Duration) *CampaignScheduler {
+	return &CampaignScheduler{campaigns: campaigns, lock: lock, holder: holder, interval: interval}
+}
+
+// Run applies the campaign schedule on every tick until ctx is cancelled,
+// then hands the lock back so another replica can take over immediately.
+func (w *CampaignScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := w.lock.Release(context.Background(), campaignSchedulerLock, w.holder); err != nil {
+			log.Printf("Campaign scheduler: releasing lock: %v", err)
+		}
+	}()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *CampaignScheduler) tick(ctx context.Context) {
+	// The lease outlives a couple of missed ticks so a slow tick does not
+	// hand leadership to another replica mid-run.
+	leader, err := w.lock.Acquire(ctx, campaignSchedulerLock, w.holder, 3*w.interval)
+	if err != nil {
+		log.Printf("Campaign scheduler: acquiring lock: %v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	started, ended, err := w.campaigns.ApplySchedule(ctx, time.Now())
+	if err != nil {
+		log.Printf("Campaign scheduler: %v", err)
+	}
+	if started > 0 || ended > 0 {
+		log.Printf("Campaign scheduler: started %d, ended %d campaign(s)", started, ended)
+	}
+}
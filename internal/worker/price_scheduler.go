@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"time"
+)
+
+const priceSchedulerLock = "price-scheduler"
+
+// PriceScheduler applies scheduled price changes once they fall due. Every
+// replica runs one, but only the holder of the leader lock does any work, so
+// each change is applied once.
+type PriceScheduler struct {
+	pricing  domain.PricingUseCase
+	lock     domain.LeaderLock
+	holder   string
+	interval time.Duration
+}
+
+func NewPriceScheduler(pricing domain.PricingUseCase, lock domain.LeaderLock, holder string, interval time.Duration) *PriceScheduler {
+	return &PriceScheduler{pricing: pricing, lock: lock, holder: holder, interval: interval}
+}
+
+// Run applies due price changes on every tick until ctx is cancelled, then
+// hands the lock back so another replica can take over immediately.
+func (w *PriceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := w.lock.Release(context.Background(), priceSchedulerLock, w.holder); err != nil {
+			log.Printf("Price scheduler: releasing lock: %v", err)
+		}
+	}()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *PriceScheduler) tick(ctx context.Context) {
+	leader, err := w.lock.Acquire(ctx, priceSchedulerLock, w.holder, 3*w.interval)
+	if err != nil {
+		log.Printf("Price scheduler: acquiring lock: %v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	applied, err := w.pricing.ApplyDue(ctx, time.Now())
+	if err != nil {
+		log.Printf("Price scheduler: %v", err)
+	}
+	if applied > 0 {
+		log.Printf("Price scheduler: applied %d scheduled price change(s)", applied)
+	}
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"play-to-win-api/internal/domain"
+	"time"
+)
+
+const recommendationRefresherLock = "recommendation-refresher"
+
+// RecommendationRefresher rebuilds the co-purchase table. Every replica runs
+// one, but only the holder of the leader lock does any work, so rebuilds
+// never race each other to replace the table.
+type RecommendationRefresher struct {
+	recommendations domain.RecommendationUseCase
+	lock            domain.LeaderLock
+	holder          string
+	interval        time.Duration
+}
+
+func NewRecommendationRefresher(recommendations domain.RecommendationUseCase, lock domain.LeaderLock, holder string, interval time.Duration) *RecommendationRefresher {
+	return &RecommendationRefresher{recommendations: recommendations, lock: lock, holder: holder, interval: interval}
+}
+
+// Run rebuilds the co-purchase table on every tick until ctx is cancelled,
+// then hands the lock back so another replica can take over immediately.
+func (w *RecommendationRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer func() {
+		if err := w.lock.Release(context.Background(), recommendationRefresherLock, w.holder); err != nil {
+			log.Printf("Recommendation refresher: releasing lock: %v", err)
+		}
+	}()
+
+	for {
+		w.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *RecommendationRefresher) tick(ctx context.Context) {
+	leader, err := w.lock.Acquire(ctx, recommendationRefresherLock, w.holder, 3*w.interval)
+	if err != nil {
+		log.Printf("Recommendation refresher: acquiring lock: %v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	pairs, err := w.recommendations.Refresh(ctx)
+	if err != nil {
+		log.Printf("Recommendation refresher: %v", err)
+		return
+	}
+	log.Printf("Recommendation refresher: stored %d product pair(s)", pairs)
+}
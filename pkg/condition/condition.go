@@ -0,0 +1,115 @@
+// Package condition compiles small boolean expressions such as
+//
+//	cart.subtotal >= 500 && user.orders == 0 && item.category in ["Shoes"]
+//
+// against a fixed set of typed fields. Expressions can only compare fields and
+// literals and combine the results, so they always terminate and cannot reach
+// anything outside the values they are given.
+package condition
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxLength = 1000
+	maxDepth  = 32
+)
+
+type Type int
+
+const (
+	Bool Type = iota + 1
+	Number
+	String
+	numberList
+	stringList
+)
+
+func (t Type) String() string {
+	switch t {
+	case Bool:
+		return "bool"
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case numberList:
+		return "list of numbers"
+	case stringList:
+		return "list of strings"
+	}
+	return "unknown"
+}
+
+// Schema names the fields an expression may use and their types.
+type Schema map[string]Type
+
+// Vars holds field values. Numbers may be any Go integer or float type; a
+// missing field reads as its type's zero value.
+type Vars map[string]interface{}
+
+var ErrSyntax = errors.New("invalid condition")
+
+// Error points at the offending position in the expression.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos+1, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrSyntax
+}
+
+// Program is a compiled expression, safe for concurrent use.
+type Program struct {
+	root   node
+	fields map[string]bool
+}
+
+// Compile parses src and type-checks it against schema. The expression must
+// evaluate to a bool.
+func Compile(src string, schema Schema) (*Program, error) {
+	if len(src) > maxLength {
+		return nil, &Error{Pos: maxLength, Msg: "expression is too long"}
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, schema: schema, fields: map[string]bool{}}
+	root, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+	}
+	if root.typ() != Bool {
+		return nil, &Error{Pos: 0, Msg: fmt.Sprintf("expression is a %s, not a condition", root.typ())}
+	}
+	return &Program{root: root, fields: p.fields}, nil
+}
+
+// Eval runs the program against vars.
+func (p *Program) Eval(vars Vars) bool {
+	return p.root.eval(vars).(bool)
+}
+
+// Uses reports whether the expression reads any field under prefix, such as
+// "item." or "user.".
+func (p *Program) Uses(prefix string) bool {
+	for field := range p.fields {
+		if strings.HasPrefix(field, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var schema = Schema{
+	"cart.subtotal": Number,
+	"user.orders":   Number,
+	"user.role":     String,
+	"item.category": String,
+	"item.on_sale":  Bool,
+}
+
+func TestCompile_Eval(t *testing.T) {
+	program, err := Compile(`cart.subtotal >= 500 && user.orders == 0 && item.category in ["Shoes", "Bags"]`, schema)
+	require.NoError(t, err)
+
+	assert.True(t, program.Eval(Vars{"cart.subtotal": 650.0, "user.orders": int64(0), "item.category": "shoes"}))
+	assert.False(t, program.Eval(Vars{"cart.subtotal": 650.0, "user.orders": int64(2), "item.category": "Shoes"}))
+	assert.False(t, program.Eval(Vars{"cart.subtotal": 120.0, "user.orders": int64(0), "item.category": "Shoes"}))
+	assert.True(t, program.Uses("item."))
+	assert.False(t, program.Uses("order."))
+}
+
+func TestCompile_Precedence(t *testing.T) {
+	program, err := Compile(`!item.on_sale || user.role == 'vip' && cart.subtotal > 100`, schema)
+	require.NoError(t, err)
+
+	assert.True(t, program.Eval(Vars{"item.on_sale": false}))
+	assert.False(t, program.Eval(Vars{"item.on_sale": true, "user.role": "vip", "cart.subtotal": 50}))
+	assert.True(t, program.Eval(Vars{"item.on_sale": true, "user.role": "VIP", "cart.subtotal": 150}))
+}
+
+func TestCompile_Rejects(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":     `cart.total > 1`,
+		"type mismatch":     `user.role == 3`,
+		"ordering strings":  `user.role > "a"`,
+		"mixed list":        `item.category in ["Shoes", 1]`,
+		"not a condition":   `cart.subtotal`,
+		"logic on numbers":  `cart.subtotal && true`,
+		"unbalanced":        `(cart.subtotal > 1`,
+		"unterminated":      `user.role == "vip`,
+		"trailing tokens":   `true false`,
+		"function call":     `len(item.category) > 1`,
+		"empty":             ``,
+		"number in strings": `cart.subtotal in ["a"]`,
+	}
+	for name, src := range tests {
+		_, err := Compile(src, schema)
+		assert.ErrorIs(t, err, ErrSyntax, name)
+	}
+}
@@ -0,0 +1,148 @@
+package condition
+
+import "strings"
+
+type node interface {
+	typ() Type
+	eval(vars Vars) interface{}
+}
+
+type literal struct {
+	t     Type
+	value interface{}
+}
+
+func (n *literal) typ() Type                  { return n.t }
+func (n *literal) eval(vars Vars) interface{} { return n.value }
+
+type field struct {
+	name string
+	t    Type
+}
+
+func (n *field) typ() Type { return n.t }
+
+func (n *field) eval(vars Vars) interface{} {
+	value := vars[n.name]
+	switch n.t {
+	case Number:
+		return toNumber(value)
+	case String:
+		s, _ := value.(string)
+		return s
+	default:
+		b, _ := value.(bool)
+		return b
+	}
+}
+
+func toNumber(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return 0
+}
+
+type list struct {
+	t       Type
+	numbers []float64
+	strings []string
+}
+
+func (n *list) typ() Type                  { return n.t }
+func (n *list) eval(vars Vars) interface{} { return n }
+
+type logical struct {
+	and         bool
+	left, right node
+}
+
+func (n *logical) typ() Type { return Bool }
+
+func (n *logical) eval(vars Vars) interface{} {
+	left := n.left.eval(vars).(bool)
+	if n.and != left {
+		return left
+	}
+	return n.right.eval(vars).(bool)
+}
+
+type not struct {
+	operand node
+}
+
+func (n *not) typ() Type                  { return Bool }
+func (n *not) eval(vars Vars) interface{} { return !n.operand.eval(vars).(bool) }
+
+// equality compares strings case-insensitively, so categories and roles match
+// however they were typed.
+type equality struct {
+	negate      bool
+	left, right node
+}
+
+func (n *equality) typ() Type { return Bool }
+
+func (n *equality) eval(vars Vars) interface{} {
+	left, right := n.left.eval(vars), n.right.eval(vars)
+	equal := left == right
+	if s, ok := left.(string); ok {
+		equal = strings.EqualFold(s, right.(string))
+	}
+	return equal != n.negate
+}
+
+type ordering struct {
+	op          string
+	left, right node
+}
+
+func (n *ordering) typ() Type { return Bool }
+
+func (n *ordering) eval(vars Vars) interface{} {
+	left, right := n.left.eval(vars).(float64), n.right.eval(vars).(float64)
+	switch n.op {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	default:
+		return left >= right
+	}
+}
+
+type membership struct {
+	left node
+	list *list
+}
+
+func (n *membership) typ() Type { return Bool }
+
+func (n *membership) eval(vars Vars) interface{} {
+	switch value := n.left.eval(vars).(type) {
+	case float64:
+		for _, candidate := range n.list.numbers {
+			if candidate == value {
+				return true
+			}
+		}
+	case string:
+		for _, candidate := range n.list.strings {
+			if strings.EqualFold(candidate, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package condition
+
+import (
+	"fmt"
+)
+
+type parser struct {
+	tokens []token
+	next   int
+	schema Schema
+	fields map[string]bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) isOp(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokOp && tok.text == text
+}
+
+func (p *parser) parseExpr(depth int) (node, error) {
+	if depth > maxDepth {
+		return nil, &Error{Pos: p.peek().pos, Msg: "expression is nested too deeply"}
+	}
+	return p.parseOr(depth)
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		tok := p.advance()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := expectBool(tok, left, right); err != nil {
+			return nil, err
+		}
+		left = &logical{and: false, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		tok := p.advance()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		if err := expectBool(tok, left, right); err != nil {
+			return nil, err
+		}
+		left = &logical{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if p.isOp("!") {
+		tok := p.advance()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := expectBool(tok, operand); err != nil {
+			return nil, err
+		}
+		return &not{operand: operand}, nil
+	}
+	return p.parseComparison(depth)
+}
+
+func (p *parser) parseComparison(depth int) (node, error) {
+	left, err := p.parsePrimary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	switch {
+	case tok.kind == tokIn:
+		p.advance()
+		right, err := p.parsePrimary(depth)
+		if err != nil {
+			return nil, err
+		}
+		if (left.typ() == Number && right.typ() != numberList) || (left.typ() == String && right.typ() != stringList) ||
+			(left.typ() != Number && left.typ() != String) {
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("cannot look for a %s in a %s", left.typ(), right.typ())}
+		}
+		return &membership{left: left, list: right.(*list)}, nil
+	case tok.kind == tokOp && (tok.text == "==" || tok.text == "!="):
+		p.advance()
+		right, err := p.parsePrimary(depth)
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != right.typ() || left.typ() == numberList || left.typ() == stringList {
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("cannot compare a %s with a %s", left.typ(), right.typ())}
+		}
+		return &equality{negate: tok.text == "!=", left: left, right: right}, nil
+	case tok.kind == tokOp && (tok.text == "<" || tok.text == "<=" || tok.text == ">" || tok.text == ">="):
+		p.advance()
+		right, err := p.parsePrimary(depth)
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != Number || right.typ() != Number {
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("%s needs numbers, got a %s and a %s", tok.text, left.typ(), right.typ())}
+		}
+		return &ordering{op: tok.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parsePrimary(depth int) (node, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokNumber:
+		return &literal{t: Number, value: tok.num}, nil
+	case tokString:
+		return &literal{t: String, value: tok.str}, nil
+	case tokTrue, tokFalse:
+		return &literal{t: Bool, value: tok.kind == tokTrue}, nil
+	case tokIdent:
+		t, ok := p.schema[tok.text]
+		if !ok {
+			return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %q", tok.text)}
+		}
+		p.fields[tok.text] = true
+		return &field{name: tok.text, t: t}, nil
+	case tokLParen:
+		inner, err := p.parseExpr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokRParen {
+			return nil, &Error{Pos: closing.pos, Msg: fmt.Sprintf("expected ) but found %q", closing.text)}
+		}
+		return inner, nil
+	case tokLBracket:
+		return p.parseList(tok)
+	}
+	return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+}
+
+// parseList reads a non-empty list of literals of one type.
+func (p *parser) parseList(open token) (node, error) {
+	l := &list{}
+	for {
+		tok := p.advance()
+		var t Type
+		switch tok.kind {
+		case tokNumber:
+			t = numberList
+			l.numbers = append(l.numbers, tok.num)
+		case tokString:
+			t = stringList
+			l.strings = append(l.strings, tok.str)
+		default:
+			return nil, &Error{Pos: tok.pos, Msg: "lists may only hold numbers or strings"}
+		}
+		if l.t != 0 && l.t != t {
+			return nil, &Error{Pos: tok.pos, Msg: "lists cannot mix numbers and strings"}
+		}
+		l.t = t
+
+		switch sep := p.advance(); sep.kind {
+		case tokComma:
+			continue
+		case tokRBracket:
+			return l, nil
+		default:
+			return nil, &Error{Pos: sep.pos, Msg: fmt.Sprintf("expected , or ] but found %q", sep.text)}
+		}
+	}
+}
+
+func expectBool(op token, operands ...node) error {
+	for _, operand := range operands {
+		if operand.typ() != Bool {
+			return &Error{Pos: op.pos, Msg: fmt.Sprintf("%s needs conditions, got a %s", op.text, operand.typ())}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxLength caps generated slugs so they stay readable in URLs.
+const MaxLength = 80
+
+var latin = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'ÿ': "y", 'ß': "ss",
+}
+
+// Make turns s into a lowercase, hyphen separated ASCII slug. Thai text is
+// transliterated to Latin; anything else that cannot be represented is
+// treated as a word break.
+func Make(s string) string {
+	var b strings.Builder
+	pendingDash := false
+	write := func(part string) {
+		if part == "" {
+			return
+		}
+		if pendingDash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingDash = false
+		b.WriteString(part)
+	}
+
+	runes := []rune(strings.ToLower(s))
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case isThai(r):
+			end := i
+			for end < len(runes) && isThai(runes[end]) {
+				end++
+			}
+			write(Transliterate(string(runes[i:end])))
+			i = end
+			continue
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case latin[r] != "":
+			write(latin[r])
+		case unicode.Is(unicode.Mn, r):
+		default:
+			pendingDash = true
+		}
+		i++
+	}
+
+	return truncate(b.String(), MaxLength)
+}
+
+func truncate(slug string, max int) string {
+	if len(slug) <= max {
+		return slug
+	}
+	slug = slug[:max]
+	if cut := strings.LastIndexByte(slug, '-'); cut > max/2 {
+		slug = slug[:cut]
+	}
+	return strings.Trim(slug, "-")
+}
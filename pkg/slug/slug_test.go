@@ -0,0 +1,51 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	cases := map[string]string{
+		"Gaming Mouse X-100":   "gaming-mouse-x-100",
+		"  Crème Brûlée!!  ":   "creme-brulee",
+		"เสื้อยืด สีขาว":       "sueayuet-sikhao",
+		"Nike กรุงเทพ Edition": "nike-krungthep-edition",
+		"***":                  "",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, Make(in), in)
+	}
+}
+
+func TestMake_TruncatesAtWordBoundary(t *testing.T) {
+	got := Make(strings.Repeat("keyboard ", 20))
+	assert.LessOrEqual(t, len(got), MaxLength)
+	assert.False(t, strings.HasSuffix(got, "-"))
+	assert.True(t, strings.HasSuffix(got, "keyboard"))
+}
+
+func TestTransliterate(t *testing.T) {
+	cases := map[string]string{
+		"สวัสดี":  "sawatdi",
+		"ไทย":     "thai",
+		"ข้าว":    "khao",
+		"คน":      "khon",
+		"แมว":     "maeo",
+		"หมา":     "ma",
+		"ใหม่":    "mai",
+		"ตัว":     "tua",
+		"ความ":    "khwam",
+		"เรียน":   "rian",
+		"เมือง":   "mueang",
+		"น้ำ":     "nam",
+		"เก้าอี้": "kaoi",
+		"ครีม":    "khrim",
+		"๒๕๖๗":    "2567",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, Transliterate(in), in)
+	}
+}
@@ -0,0 +1,260 @@
+package slug
+
+import "strings"
+
+// Thai romanisation follows the Royal Thai General System (RTGS) closely
+// enough for URLs. Syllable boundaries are inferred from the script alone,
+// so words whose reading depends on a dictionary come out approximate.
+
+var thaiInitials = map[rune]string{
+	'ก': "k", 'ข': "kh", 'ฃ': "kh", 'ค': "kh", 'ฅ': "kh", 'ฆ': "kh", 'ง': "ng",
+	'จ': "ch", 'ฉ': "ch", 'ช': "ch", 'ซ': "s", 'ฌ': "ch", 'ญ': "y",
+	'ฎ': "d", 'ฏ': "t", 'ฐ': "th", 'ฑ': "th", 'ฒ': "th", 'ณ': "n",
+	'ด': "d", 'ต': "t", 'ถ': "th", 'ท': "th", 'ธ': "th", 'น': "n",
+	'บ': "b", 'ป': "p", 'ผ': "ph", 'ฝ': "f", 'พ': "ph", 'ฟ': "f", 'ภ': "ph", 'ม': "m",
+	'ย': "y", 'ร': "r", 'ล': "l", 'ว': "w", 'ศ': "s", 'ษ': "s", 'ส': "s",
+	'ห': "h", 'ฬ': "l", 'อ': "", 'ฮ': "h",
+}
+
+var thaiFinals = map[rune]string{
+	'ก': "k", 'ข': "k", 'ฃ': "k", 'ค': "k", 'ฅ': "k", 'ฆ': "k", 'ง': "ng",
+	'จ': "t", 'ฉ': "t", 'ช': "t", 'ซ': "t", 'ฌ': "t", 'ญ': "n",
+	'ฎ': "t", 'ฏ': "t", 'ฐ': "t", 'ฑ': "t", 'ฒ': "t", 'ณ': "n",
+	'ด': "t", 'ต': "t", 'ถ': "t", 'ท': "t", 'ธ': "t", 'น': "n",
+	'บ': "p", 'ป': "p", 'ผ': "p", 'ฝ': "p", 'พ': "p", 'ฟ': "p", 'ภ': "p", 'ม': "m",
+	'ย': "i", 'ร': "n", 'ล': "n", 'ว': "o", 'ศ': "t", 'ษ': "t", 'ส': "t",
+	'ห': "", 'ฬ': "n", 'อ': "", 'ฮ': "",
+}
+
+var thaiVowels = map[rune]string{
+	'ะ': "a", 'ั': "a", 'า': "a", 'ำ': "am", 'ิ': "i", 'ี': "i",
+	'ึ': "ue", 'ื': "ue", 'ุ': "u", 'ู': "u", 'ๅ': "",
+}
+
+const (
+	maiTaikhu   = '็'
+	thanthakhat = '์'
+)
+
+func isThai(r rune) bool { return r >= 0x0E00 && r <= 0x0E7F }
+
+func isThaiConsonant(r rune) bool { return r >= 'ก' && r <= 'ฮ' && r != 'ฤ' && r != 'ฦ' }
+
+func isLeadingVowel(r rune) bool { return r >= 'เ' && r <= 'ไ' }
+
+func isToneMark(r rune) bool { return r >= '่' && r <= '๋' }
+
+func isFollowingVowel(r rune) bool {
+	_, ok := thaiVowels[r]
+	return ok || r == maiTaikhu
+}
+
+// clusterable initials may be followed by ร, ล or ว in a true consonant cluster.
+func isClusterable(r rune) bool { return strings.ContainsRune("กขคปพต", r) }
+
+func isSonorant(r rune) bool { return strings.ContainsRune("งญนมยรลว", r) }
+
+// Transliterate romanises Thai text. Non-Thai runes are dropped.
+func Transliterate(s string) string {
+	t := &thaiTransliterator{runes: []rune(s)}
+	for t.i < len(t.runes) {
+		t.step()
+	}
+	if t.onset && !t.vowel {
+		t.out.WriteString("o")
+	}
+	return t.out.String()
+}
+
+type thaiTransliterator struct {
+	runes []rune
+	i     int
+	out   strings.Builder
+
+	lead      rune
+	onset     bool
+	vowel     bool
+	lastVowel string
+}
+
+// peek returns the index of the n-th rune after position from, ignoring tone
+// marks, or -1 when the text runs out.
+func (t *thaiTransliterator) peek(from, n int) int {
+	for j := from + 1; j < len(t.runes); j++ {
+		if isToneMark(t.runes[j]) {
+			continue
+		}
+		if n--; n == 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+func (t *thaiTransliterator) at(j int) rune {
+	if j < 0 {
+		return 0
+	}
+	return t.runes[j]
+}
+
+func (t *thaiTransliterator) step() {
+	r := t.runes[t.i]
+	switch {
+	case r >= '๐' && r <= '๙':
+		t.endSyllable()
+		t.out.WriteRune('0' + r - '๐')
+		t.i++
+	case r == 'ฤ' || r == 'ฦ':
+		t.endSyllable()
+		t.out.WriteString(map[rune]string{'ฤ': "rue", 'ฦ': "lue"}[r])
+		t.i++
+	case isLeadingVowel(r):
+		t.endSyllable()
+		t.lead = r
+		t.i++
+	case isThaiConsonant(r):
+		t.consonant(r)
+	case isFollowingVowel(r):
+		t.followingVowel(r)
+	default:
+		t.i++
+	}
+}
+
+func (t *thaiTransliterator) endSyllable() {
+	t.onset, t.vowel, t.lead = false, false, 0
+}
+
+func (t *thaiTransliterator) setVowel(v string) {
+	t.out.WriteString(v)
+	t.vowel = true
+	t.lastVowel = v
+}
+
+func (t *thaiTransliterator) consonant(r rune) {
+	next := t.peek(t.i, 1)
+	if t.at(next) == thanthakhat {
+		t.i = next + 1
+		return
+	}
+	startsSyllable := isFollowingVowel(t.at(next))
+
+	switch {
+	case t.lead != 0 || !t.onset:
+		t.initial(r)
+	case !t.vowel && r == 'อ' && !startsSyllable:
+		t.setVowel("o")
+		t.i++
+	case !t.vowel && r == 'ว' && isThaiConsonant(t.at(next)):
+		t.setVowel("ua")
+		t.i++
+	case startsSyllable:
+		if !t.vowel {
+			t.out.WriteString("a")
+		}
+		t.onset, t.vowel = false, false
+		t.initial(r)
+	default:
+		if !t.vowel {
+			t.setVowel("o")
+		}
+		t.final(r)
+	}
+}
+
+func (t *thaiTransliterator) initial(r rune) {
+	next := t.peek(t.i, 1)
+	if (r == 'ห' && isSonorant(t.at(next))) || (r == 'อ' && t.at(next) == 'ย') {
+		t.i = next
+		return
+	}
+
+	t.out.WriteString(thaiInitials[r])
+	t.onset, t.vowel = true, false
+	t.i++
+
+	second := t.at(next)
+	if isClusterable(r) && (second == 'ร' || second == 'ล' || second == 'ว') {
+		if t.lead != 0 || isFollowingVowel(t.at(t.peek(next, 1))) {
+			t.out.WriteString(thaiInitials[second])
+			t.i = next + 1
+		}
+	}
+
+	if t.lead != 0 {
+		t.setVowel(t.leadVowel())
+		t.lead = 0
+	}
+}
+
+// leadVowel resolves a leading vowel together with the signs written after
+// the initial consonant that complete it, consuming those signs.
+func (t *thaiTransliterator) leadVowel() string {
+	first := t.peek(t.i-1, 1)
+	second := t.peek(t.i-1, 2)
+	consume := func(j int, v string) string {
+		t.i = j + 1
+		if after := t.peek(j, 1); t.at(after) == 'ะ' {
+			t.i = after + 1
+		}
+		return v
+	}
+
+	switch t.lead {
+	case 'เ':
+		switch {
+		case t.at(first) == 'ี' && t.at(second) == 'ย':
+			return consume(second, "ia")
+		case t.at(first) == 'ื' && t.at(second) == 'อ':
+			return consume(second, "uea")
+		case t.at(first) == 'า':
+			return consume(first, "ao")
+		case t.at(first) == 'ิ', t.at(first) == 'อ':
+			return consume(first, "oe")
+		case t.at(first) == maiTaikhu, t.at(first) == 'ะ':
+			return consume(first, "e")
+		}
+		return "e"
+	case 'แ':
+		if t.at(first) == maiTaikhu || t.at(first) == 'ะ' {
+			return consume(first, "ae")
+		}
+		return "ae"
+	case 'โ':
+		if t.at(first) == 'ะ' {
+			return consume(first, "o")
+		}
+		return "o"
+	default:
+		return "ai"
+	}
+}
+
+func (t *thaiTransliterator) followingVowel(r rune) {
+	next := t.peek(t.i, 1)
+	switch {
+	case r == 'ั' && t.at(next) == 'ว':
+		t.setVowel("ua")
+		t.i = next + 1
+	case r == 'ื' && t.at(next) == 'อ':
+		t.setVowel("ue")
+		t.i = next + 1
+	case r == maiTaikhu:
+		t.i++
+	default:
+		t.setVowel(thaiVowels[r])
+		t.i++
+	}
+}
+
+func (t *thaiTransliterator) final(r rune) {
+	switch {
+	case r == 'ย' && strings.HasSuffix(t.lastVowel, "i"):
+	case r == 'ว' && strings.HasSuffix(t.lastVowel, "o"):
+	default:
+		t.out.WriteString(thaiFinals[r])
+	}
+	t.onset, t.vowel = false, false
+	t.i++
+}
@@ -0,0 +1,93 @@
+package textsearch
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	NameWeight        = 10
+	DescriptionWeight = 5
+	ContentWeight     = 1
+)
+
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.Is(unicode.Mn, r)
+	})
+}
+
+// MaxEdits is the number of typos tolerated for a term of the given length.
+func MaxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Distance is the optimal string alignment distance between a and b, so an
+// adjacent transposition counts as a single typo.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// FuzzyPattern builds a regular expression matching any word within one edit
+// of term, for backends that have no native typo tolerance.
+func FuzzyPattern(term string) string {
+	runes := []rune(term)
+	if MaxEdits(term) == 0 {
+		return regexp.QuoteMeta(term)
+	}
+
+	variants := make([]string, 0, 4*len(runes)+1)
+	for i := 0; i <= len(runes); i++ {
+		head := regexp.QuoteMeta(string(runes[:i]))
+		if i < len(runes) {
+			tail := regexp.QuoteMeta(string(runes[i+1:]))
+			variants = append(variants, head+"."+tail, head+tail)
+		}
+		if i+1 < len(runes) {
+			swapped := regexp.QuoteMeta(string([]rune{runes[i+1], runes[i]}))
+			variants = append(variants, head+swapped+regexp.QuoteMeta(string(runes[i+2:])))
+		}
+		variants = append(variants, head+"."+regexp.QuoteMeta(string(runes[i:])))
+	}
+	return "(" + strings.Join(variants, "|") + ")"
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,86 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+const Quality = 80
+
+type Result struct {
+	Data   []byte
+	Width  int
+	Height int
+}
+
+func DecodeConfig(data []byte) (image.Config, string, error) {
+	return image.DecodeConfig(bytes.NewReader(data))
+}
+
+// Generate scales the image down so neither side exceeds maxSize and encodes
+// it as JPEG. Images already within bounds are re-encoded at their own size.
+func Generate(data []byte, maxSize int) (*Result, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), maxSize)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	scaleX := float64(bounds.Dx()) / float64(width)
+	scaleY := float64(bounds.Dy()) / float64(height)
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + int(float64(y)*scaleY)
+		y1 := maxInt(y0+1, bounds.Min.Y+int(float64(y+1)*scaleY))
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + int(float64(x)*scaleX)
+			x1 := maxInt(x0+1, bounds.Min.X+int(float64(x+1)*scaleX))
+			dst.Set(x, y, average(src, x0, y0, x1, y1))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: Quality}); err != nil {
+		return nil, err
+	}
+	return &Result{Data: buf.Bytes(), Width: width, Height: height}, nil
+}
+
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, maxInt(1, height*maxSize/width)
+	}
+	return maxInt(1, width*maxSize/height), maxSize
+}
+
+// average box-filters the source pixels, flattening transparency onto white
+// since JPEG has no alpha channel.
+func average(src image.Image, x0, y0, x1, y1 int) color.RGBA {
+	var r, g, b, n uint32
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			cr, cg, cb, ca := src.At(x, y).RGBA()
+			white := 0xffff - ca
+			r, g, b = r+cr+white, g+cg+white, b+cb+white
+			n++
+		}
+	}
+	return color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(b / n >> 8), A: 0xff}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}